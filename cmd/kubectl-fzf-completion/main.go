@@ -46,15 +46,14 @@ func completeFun(cmd *cobra.Command, cmdArgs []string) {
 		os.Exit(FallbackExitCode)
 	}
 
-	firstWord := args[0]
-	verbs := []string{"get", "exec", "logs", "label", "describe", "delete", "annotate", "edit", "scale"}
-	if !util.IsStringIn(firstWord, verbs) {
+	firstWord, args := parse.SplitVerb(args)
+	if !completion.IsSupportedVerb(firstWord) && !completion.IsContextCompletion(firstWord, args) {
 		os.Exit(FallbackExitCode)
 	}
-	args = args[1:]
 
 	fetchConfigCli := fetcher.GetFetchConfigCli()
 	f := fetcher.NewFetcher(&fetchConfigCli)
+	f.SetConfigOverrides(completion.GetConfigOverrides(firstWord, args))
 	err := f.LoadFetcherState()
 	if err != nil {
 		logrus.Warnf("Error loading fetcher state")
@@ -89,7 +88,7 @@ func completeFun(cmd *cobra.Command, cmdArgs []string) {
 	formattedComps := completionResults.GetFormattedOutput()
 
 	query := completion.ExtractQueryFromArgs(args)
-	fzfResult, err := fzf.CallFzf(formattedComps, query)
+	fzfResult, err := fzf.CallFzf(formattedComps, query, completionResults.MultiSelect)
 	if err != nil {
 		if e, ok := err.(fzf.InterruptedCommandError); ok {
 			logrus.Infof("Fzf was interrupted: %s", e)
@@ -101,6 +100,25 @@ func completeFun(cmd *cobra.Command, cmdArgs []string) {
 	if err != nil {
 		logrus.Fatalf("Process result error: %s", err)
 	}
+
+	containerPick, err := completion.GetContainerPick(firstWord, args, f, fzfResult)
+	if err != nil {
+		logrus.Warnf("Error getting containers, skipping container pick: %s", err)
+	} else if containerPick != nil {
+		containerFzfResult, err := fzf.CallFzf(containerPick.GetFormattedOutput(), "", false)
+		if err != nil {
+			if e, ok := err.(fzf.InterruptedCommandError); ok {
+				logrus.Infof("Fzf was interrupted, skipping container pick: %s", e)
+				fmt.Print(res)
+				return
+			}
+			logrus.Fatalf("Call fzf error: %s", err)
+		}
+		res, err = results.ProcessContainerPickResult(firstWord, res, containerFzfResult)
+		if err != nil {
+			logrus.Fatalf("Process container result error: %s", err)
+		}
+	}
 	fmt.Print(res)
 }
 
@@ -47,6 +47,7 @@ func main() {
 	store.SetStoreConfigCli(rootFlags)
 	httpserver.SetHttpServerConfigFlags(rootFlags)
 	resourcewatcher.SetResourceWatcherCli(rootFlags)
+	kubectlfzfserver.SetKubectlFzfServerCli(rootFlags)
 	util.SetCommonCliFlags(rootFlags, "info")
 	err := viper.BindPFlags(rootFlags)
 	util.FatalIf(err)
@@ -33,6 +33,8 @@ func getResourceCompletion(ctx context.Context, r resources.ResourceType, namesp
 	if err != nil {
 		return nil, err
 	}
+	setLastWarnings(ctx, r, resources, fetchConfig)
+	setNodePodCounts(r, resources, fetchConfig)
 	comps := []string{}
 	logrus.Debugf("Filterting with namespace %v", namespace)
 	for _, resource := range resources {
@@ -43,6 +45,37 @@ func getResourceCompletion(ctx context.Context, r resources.ResourceType, namesp
 	return comps, nil
 }
 
+// getApiResourceCompletion lists the api resources accepted by the verb.
+// Resources present in multiple groups are displayed with their group.
+func getApiResourceCompletion(ctx context.Context, rule *VerbRule, fetchConfig *fetcher.Fetcher) ([]string, error) {
+	k8sResources, err := fetchConfig.GetResources(ctx, resources.ResourceTypeApiResource)
+	if err != nil {
+		return nil, err
+	}
+	apiResources := []resources.APIResource{}
+	nameCount := map[string]int{}
+	for _, k8sResource := range k8sResources {
+		apiResourceList, ok := k8sResource.(*resources.APIResourceList)
+		if !ok {
+			continue
+		}
+		for _, apiResource := range apiResourceList.ApiResources {
+			nameCount[apiResource.Name]++
+			if rule == nil || rule.IsApiResourceAllowed(&apiResource) {
+				apiResources = append(apiResources, apiResource)
+			}
+		}
+	}
+	comps := []string{}
+	for _, apiResource := range apiResources {
+		if nameCount[apiResource.Name] > 1 {
+			apiResource.Name = apiResource.QualifiedName()
+		}
+		comps = append(comps, apiResource.ToStrings()...)
+	}
+	return comps, nil
+}
+
 func ExtractQueryFromArgs(cmdArgs []string) string {
 	if len(cmdArgs) == 0 {
 		return ""
@@ -51,13 +84,53 @@ func ExtractQueryFromArgs(cmdArgs []string) string {
 	if latestArg == " " {
 		return ""
 	}
+	if prefix, ok := parse.ParseDataKeyPrefix(latestArg); ok {
+		// Only the partial key is used as query
+		return latestArg[len(prefix):]
+	}
+	if _, name, ok := resources.ParseTypeName(latestArg); ok {
+		// Only the partial name is used as query
+		return name
+	}
+	if resources.IsResourceTypeListPrefix(latestArg) {
+		// Only the type being completed is used as query
+		return latestArg[strings.LastIndex(latestArg, ",")+1:]
+	}
 	return latestArg
 }
 
 func processCommandArgsWithFetchConfig(ctx context.Context, fetchConfig *fetcher.Fetcher,
 	cmdVerb string, args []string) (*CompletionResult, error) {
 	var err error
-	resourceType, flagCompletion, err := parse.ParseFlagAndResources(cmdVerb, args)
+	if IsContextCompletion(cmdVerb, args) {
+		return processContext(fetchConfig), nil
+	}
+	if IsSubVerbCompletion(cmdVerb, args) && parse.CheckFlagManaged(cmdVerb, args) == parse.FlagNone {
+		return &CompletionResult{
+			Cluster:     fetchConfig.GetContext(),
+			Header:      resources.SubVerbHeader,
+			Completions: verbRules[cmdVerb].getSubVerbCompletion(),
+		}, nil
+	}
+	if cmdVerb == "set" {
+		if _, _, ok := parse.ParseSetImageTarget(args); ok {
+			return processSetImage(ctx, fetchConfig, args)
+		}
+	}
+	if cmdVerb == "port-forward" && parse.CheckFlagManaged(cmdVerb, args) == parse.FlagNone {
+		return processPortForward(ctx, fetchConfig, args)
+	}
+	if cmdVerb == "taint" {
+		taintResult, err := processTaint(ctx, fetchConfig, args)
+		if taintResult != nil || err != nil {
+			return taintResult, err
+		}
+	}
+	tagEditResult, err := processTagEdit(ctx, fetchConfig, cmdVerb, args)
+	if tagEditResult != nil || err != nil {
+		return tagEditResult, err
+	}
+	resourceType, flagCompletion, err := ParseFlagAndResources(cmdVerb, args)
 	if err != nil {
 		return nil, err
 	}
@@ -71,13 +144,30 @@ func processCommandArgsWithFetchConfig(ctx context.Context, fetchConfig *fetcher
 	} else if flagCompletion == parse.FlagFieldSelector {
 		completionResult.Header, completionResult.Completions, err = GetTagResourceCompletion(ctx, resourceType, namespace, fetchConfig, TagTypeFieldSelector)
 		return completionResult, err
+	} else if flagCompletion == parse.FlagDataKey {
+		completionResult.Header = resources.DataKeyHeader
+		completionResult.Completions, err = getDataKeyCompletion(ctx, args, namespace, fetchConfig)
+		return completionResult, err
+	} else if flagCompletion == parse.FlagContainer {
+		completionResult.Header = resources.ContainerHeader
+		completionResult.Completions, err = getContainerCompletion(ctx, cmdVerb, args, fetchConfig)
+		return completionResult, err
 	}
 
 	completionResult.Header = resources.ResourceToHeader(resourceType)
-	completionResult.Completions, err = getResourceCompletion(ctx, resourceType, namespace, fetchConfig)
+	if resourceTypes := GetResourceTypes(cmdVerb, args); len(resourceTypes) > 1 {
+		completionResult.Header = resources.MultiResourceHeader
+		completionResult.Completions, err = getMultiResourceCompletion(ctx, resourceTypes, namespace, fetchConfig)
+	} else if resourceType == resources.ResourceTypeApiResource {
+		rule, _, _ := GetVerbRule(cmdVerb, args)
+		completionResult.Completions, err = getApiResourceCompletion(ctx, rule, fetchConfig)
+	} else {
+		completionResult.Completions, err = getResourceCompletion(ctx, resourceType, namespace, fetchConfig)
+	}
 	if err != nil {
 		return completionResult, errors.Wrap(err, "error getting resource completion")
 	}
+	completionResult.MultiSelect = IsMultiSelect(cmdVerb, args)
 	sort.Strings(completionResult.Completions)
 	return completionResult, err
 }
@@ -10,6 +10,8 @@ type CompletionResult struct {
 	Cluster     string
 	Header      string
 	Completions []string
+	// MultiSelect is set when multiple completions can be selected
+	MultiSelect bool
 }
 
 func (c *CompletionResult) GetFormattedOutput() string {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher/fetchertest"
@@ -155,6 +156,205 @@ func TestPodCompletionFile(t *testing.T) {
 	assert.Len(res, 7)
 }
 
+func TestLastWarningCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	res, err := getResourceCompletion(context.Background(), resources.ResourceTypePod, nil, fetchConfig)
+	require.NoError(t, err)
+	found := false
+	for _, line := range res {
+		if strings.HasPrefix(line, "kube-system\tcoredns-6d4b75cb6d-m6m4q\t") {
+			found = true
+			assert.Contains(t, line, "\tUnhealthy:_Readiness_probe_failed:_HTTP_probe_failed_with_statuscode:_503\t")
+		}
+	}
+	require.True(t, found)
+
+	res, err = getResourceCompletion(context.Background(), resources.ResourceTypeNode, nil, fetchConfig)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Contains(t, res[0], "\tNodeHasDiskPressure:_Node_minikube_status_is_now:_NodeHasDiskPressure\t")
+}
+
+func TestContainerCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	testDatas := []struct {
+		cmdArg         cmdArg
+		expectedLength int
+	}{
+		{cmdArg{"exec", []string{"-ti", "coredns-6d4b75cb6d-m6m4q", "-c", " "}}, 2},
+		{cmdArg{"logs", []string{"-n", "kube-system", "etcd-minikube", "--container="}}, 1},
+		{cmdArg{"cp", []string{"kube-system/coredns-6d4b75cb6d-m6m4q:/etc", "/tmp", "-c"}}, 2},
+		{cmdArg{"logs", []string{"-c", " "}}, 8},
+		{cmdArg{"logs", []string{"-f", "coredns-6d4b75cb6d-m6m4q", "-c", " "}}, 2},
+	}
+	for _, testData := range testDatas {
+		completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, testData.cmdArg.verb, testData.cmdArg.args)
+		require.NoError(t, err)
+		assert.Equal(t, resources.ContainerHeader, completionResults.Header)
+		require.Len(t, completionResults.Completions, testData.expectedLength, "args: %s", testData.cmdArg.args)
+	}
+}
+
+func TestContainerPick(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	containerPick, err := GetContainerPick("exec", []string{"-ti", ""}, fetchConfig, "kube-system coredns-6d4b75cb6d-m6m4q 172.17.0.3")
+	require.NoError(t, err)
+	require.NotNil(t, containerPick)
+	assert.Equal(t, []string{
+		"kube-system\tcoredns-6d4b75cb6d-m6m4q\tcoredns\tcontainer\tk8s.gcr.io/coredns/coredns:v1.8.6\tRunning\t0",
+		"kube-system\tcoredns-6d4b75cb6d-m6m4q\tdebugger\tephemeral\tbusybox:1.35\tRunning\t0",
+	}, containerPick.Completions)
+
+	// Single container pod
+	containerPick, err = GetContainerPick("exec", []string{"-ti", ""}, fetchConfig, "kube-system etcd-minikube 192.168.49.2")
+	require.NoError(t, err)
+	require.Nil(t, containerPick)
+
+	// Container already provided
+	containerPick, err = GetContainerPick("logs", []string{"-c", "coredns", ""}, fetchConfig, "kube-system coredns-6d4b75cb6d-m6m4q 172.17.0.3")
+	require.NoError(t, err)
+	require.Nil(t, containerPick)
+}
+
+func TestSetImageCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	cmdArgs := [][]string{
+		{"image", "deploy/coredns", " "},
+		{"image", "deployment.apps", "coredns", " "},
+		{"image", "-n", "kube-system", "ds/kube-proxy", ""},
+	}
+	for _, args := range cmdArgs {
+		completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "set", args)
+		require.NoError(t, err)
+		assert.Equal(t, resources.TemplateContainerHeader, completionResults.Header)
+		require.Len(t, completionResults.Completions, 1, "args: %s", args)
+	}
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "set", cmdArgs[0])
+	require.NoError(t, err)
+	assert.Equal(t, "kube-system\tcoredns\tcoredns\tk8s.gcr.io/coredns/coredns:v1.8.6", completionResults.Completions[0])
+
+	// Other resources are completed in the type/name form
+	completionResults, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "set", []string{"env", "deploy/coredns", " "})
+	require.NoError(t, err)
+	assert.Equal(t, resources.ResourceToHeader(resources.ResourceTypeDeployment), completionResults.Header)
+
+	_, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "set", []string{"env", "svc/kube-dns", " "})
+	require.ErrorAs(t, err, &resources.UnknownResourceError{})
+}
+
+func TestNodePodCountCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	for _, verb := range []string{"drain", "cordon"} {
+		completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, verb, []string{" "})
+		require.NoError(t, err)
+		require.Len(t, completionResults.Completions, 1)
+		assert.Contains(t, completionResults.Completions[0], "minikube\tcontrol-plane\tReady\tfalse\t")
+		assert.Contains(t, completionResults.Completions[0], "\tv1.24.1\t2\t7935240Ki\t7/110\t")
+	}
+}
+
+func TestDataKeyCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	testDatas := []struct {
+		cmdArg         cmdArg
+		expectedLength int
+	}{
+		{cmdArg{"get", []string{"cm", "kube-proxy", "-o", "jsonpath='{.data."}}, 2},
+		{cmdArg{"get", []string{"-n", "kube-system", "configmap/coredns", "-o=jsonpath={.data.Core"}}, 1},
+		{cmdArg{"get", []string{"configmaps", "-n", "default", "-o", "jsonpath={.data."}}, 2},
+	}
+	for _, testData := range testDatas {
+		completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, testData.cmdArg.verb, testData.cmdArg.args)
+		require.NoError(t, err)
+		assert.Equal(t, resources.DataKeyHeader, completionResults.Header)
+		require.Len(t, completionResults.Completions, testData.expectedLength, "args: %s", testData.cmdArg.args)
+	}
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "get", []string{"cm", "coredns", "-o", "jsonpath='{.data."})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kube-system\tcoredns\tCorefile"}, completionResults.Completions)
+	assert.Equal(t, "Core", ExtractQueryFromArgs([]string{"cm", "coredns", "-o", "jsonpath='{.data.Core"}))
+}
+
+func TestPortForwardCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	previousIsLocalPortFree := isLocalPortFree
+	isLocalPortFree = func(port int) bool { return port != 8443 }
+	defer func() { isLocalPortFree = previousIsLocalPortFree }()
+
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "port-forward", []string{"-n", "kube-system", " "})
+	require.NoError(t, err)
+	assert.Equal(t, resources.PortForwardTargetHeader, completionResults.Header)
+	assert.Contains(t, completionResults.Completions, "kube-system\tsvc/kube-dns\tdns:53,dns-tcp:53,metrics:9153")
+	assert.Contains(t, completionResults.Completions, "kube-system\tdeploy/coredns\tdns:53,dns-tcp:53,metrics:9153")
+	assert.Contains(t, completionResults.Completions, "kube-system\tpod/etcd-minikube\tNone")
+
+	completionResults, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "port-forward", []string{"svc/kube-dns", "-n", "kube-system", " "})
+	require.NoError(t, err)
+	assert.Equal(t, resources.PortHeader, completionResults.Header)
+	assert.Equal(t, []string{
+		"kube-system\tservice/kube-dns\t8053:dns-tcp\tdns-tcp\t53\tTCP\t53",
+		"kube-system\tservice/kube-dns\t9153:metrics\tmetrics\t9153\tTCP\t9153",
+	}, completionResults.Completions)
+
+	completionResults, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "port-forward", []string{"svc/kubernetes", " "})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default\tservice/kubernetes\t8444:https\thttps\t443\tTCP\t8443"}, completionResults.Completions)
+
+	completionResults, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "port-forward", []string{"coredns-6d4b75cb6d-m6m4q", " "})
+	require.NoError(t, err)
+	assert.Len(t, completionResults.Completions, 2)
+}
+
+func TestAnnotateCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "annotate", []string{"deploy", "coredns", "-n", "kube-system", " "})
+	require.NoError(t, err)
+	assert.Equal(t, resources.AnnotationEditHeader, completionResults.Header)
+	assert.Equal(t, []string{
+		"deployment.kubernetes.io/revision=\toverwrite\t1",
+		"deployment.kubernetes.io/revision-\tremove\t1",
+	}, completionResults.Completions)
+
+	// Target isn't provided yet
+	completionResults, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "annotate", []string{"deploy", " "})
+	require.NoError(t, err)
+	assert.Equal(t, resources.ResourceToHeader(resources.ResourceTypeDeployment), completionResults.Header)
+}
+
+func TestLabelEditCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "label", []string{"pods", "coredns-6d4b75cb6d-m6m4q", " "})
+	require.NoError(t, err)
+	assert.Equal(t, resources.LabelEditHeader, completionResults.Header)
+	assert.Equal(t, []string{
+		"k8s-app=kube-dns\toverwrite\t2",
+		"k8s-app-\tremove\t2",
+		"pod-template-hash=6d4b75cb6d\toverwrite\t0",
+		"pod-template-hash-\tremove\t0",
+		"component=\tadd\t4",
+		"tier=\tadd\t4",
+		"addonmanager.kubernetes.io/mode=\tadd\t1",
+		"integration-test=\tadd\t1",
+	}, completionResults.Completions)
+}
+
+func TestTaintCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "taint", []string{"nodes", " "})
+	require.NoError(t, err)
+	assert.Equal(t, resources.ResourceToHeader(resources.ResourceTypeNode), completionResults.Header)
+	require.Len(t, completionResults.Completions, 1)
+
+	completionResults, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "taint", []string{"nodes", "minikube", " "})
+	require.NoError(t, err)
+	assert.Equal(t, resources.TaintEditHeader, completionResults.Header)
+	assert.Equal(t, []string{
+		"dedicated:NoSchedule-\tremove\t1\tnode",
+		"node-role.kubernetes.io/control-plane:NoSchedule\tadd\t1\ttolerations",
+		"node-role.kubernetes.io/master:NoSchedule\tadd\t1\ttolerations",
+	}, completionResults.Completions)
+}
+
 func TestNamespaceFilterFile(t *testing.T) {
 	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
 
@@ -228,10 +428,11 @@ func TestHttpServerCachePod(t *testing.T) {
 
 	podCache := path.Join(tempDir, "nothing", resources.ResourceTypePod.String())
 	assert.FileExists(t, podCache)
-	require.Equal(t, fzfHttpServer.ResourceHit, 1)
+	// Pods and events for the last warnings
+	require.Equal(t, fzfHttpServer.ResourceHit, 2)
 	fetcher_state := path.Join(tempDir, "fetcher_state")
 	assert.FileExists(t, fetcher_state)
 
 	res, err = getResourceCompletion(context.Background(), resources.ResourceTypePod, nil, f)
-	require.Equal(t, fzfHttpServer.ResourceHit, 1)
+	require.Equal(t, fzfHttpServer.ResourceHit, 2)
 }
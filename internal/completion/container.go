@@ -0,0 +1,126 @@
+package completion
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/parse"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// containerVerbs are the verbs targeting a container of a pod
+var containerVerbs = []string{"exec", "logs", "attach", "cp"}
+
+// getPods returns the pods matching the namespace and name.
+// An empty name matches all pods.
+func getPods(ctx context.Context, namespace *string, name string,
+	fetchConfig *fetcher.Fetcher) ([]*resources.Pod, error) {
+	k8sResources, err := fetchConfig.GetResources(ctx, resources.ResourceTypePod)
+	if err != nil {
+		return nil, err
+	}
+	pods := []*resources.Pod{}
+	for _, k8sResource := range k8sResources {
+		pod, ok := k8sResource.(*resources.Pod)
+		if !ok {
+			continue
+		}
+		if namespace != nil && *namespace != pod.Namespace {
+			continue
+		}
+		if name != "" && name != pod.Name {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods, nil
+}
+
+// filterContainers removes the containers the verb can't target.
+// Only logs can be used on init containers.
+func filterContainers(cmdVerb string, containerInfos []resources.ContainerInfo) []resources.ContainerInfo {
+	if cmdVerb == "logs" {
+		return containerInfos
+	}
+	res := []resources.ContainerInfo{}
+	for _, c := range containerInfos {
+		if c.Type != "init" {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func podsToContainerCompletion(cmdVerb string, pods []*resources.Pod) []string {
+	comps := []string{}
+	for _, pod := range pods {
+		p := *pod
+		p.ContainerInfos = filterContainers(cmdVerb, pod.ContainerInfos)
+		comps = append(comps, p.ContainersToStrings()...)
+	}
+	sort.Strings(comps)
+	return comps
+}
+
+// getContainerCompletion lists the containers of the pod provided in the
+// arguments, or the containers of all pods if no pod was provided
+func getContainerCompletion(ctx context.Context, cmdVerb string, args []string,
+	fetchConfig *fetcher.Fetcher) ([]string, error) {
+	namespace, podName := parse.ParsePodFromArgs(cmdVerb, args)
+	logrus.Debugf("Completing containers of pod '%s', namespace %v", podName, namespace)
+	pods, err := getPods(ctx, namespace, podName, fetchConfig)
+	if err != nil {
+		return nil, err
+	}
+	return podsToContainerCompletion(cmdVerb, pods), nil
+}
+
+func hasContainerFlag(args []string) bool {
+	for _, arg := range args {
+		if arg == "-c" || arg == "--container" ||
+			strings.HasPrefix(arg, "-c=") || strings.HasPrefix(arg, "--container=") {
+			return true
+		}
+	}
+	return false
+}
+
+// GetContainerPick returns the containers of the pod selected in fzf when
+// the pod has multiple containers and no container was provided.
+// A nil result means there's no container to pick.
+func GetContainerPick(cmdVerb string, args []string, f *fetcher.Fetcher,
+	fzfResult string) (*CompletionResult, error) {
+	if !util.IsStringIn(cmdVerb, containerVerbs) || hasContainerFlag(args) ||
+		parse.CheckFlagManaged(cmdVerb, args) != parse.FlagNone {
+		return nil, nil
+	}
+	if resourceType, _, err := ParseFlagAndResources(cmdVerb, args); err != nil || resourceType != resources.ResourceTypePod {
+		// Other resources like deploy/name are resolved to a pod by kubectl
+		return nil, nil
+	}
+	resultFields := strings.Fields(fzfResult)
+	if len(resultFields) < 2 {
+		return nil, nil
+	}
+	namespace := resultFields[0]
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	pods, err := getPods(ctx, &namespace, resultFields[1], f)
+	if err != nil {
+		return nil, err
+	}
+	comps := podsToContainerCompletion(cmdVerb, pods)
+	if len(comps) <= 1 {
+		return nil, nil
+	}
+	return &CompletionResult{
+		Cluster:     f.GetContext(),
+		Header:      resources.ContainerHeader,
+		Completions: comps,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package completion
+
+import (
+	"strconv"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/clusterconfig"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/parse"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+)
+
+// IsContextCompletion returns true if a kubeconfig context needs to be
+// completed, either as value of --context or as argument of config use-context
+func IsContextCompletion(cmdVerb string, args []string) bool {
+	flagCompletion := parse.CheckFlagManaged(cmdVerb, args)
+	if flagCompletion == parse.FlagContext {
+		return true
+	}
+	if flagCompletion != parse.FlagNone {
+		return false
+	}
+	rule, ruleArgs, ok := GetVerbRule(cmdVerb, args)
+	return ok && rule.Contexts && !util.IsStringIn("--current", args) &&
+		len(getCompletedPositionalArgs(cmdVerb, ruleArgs)) == 0
+}
+
+// GetConfigOverrides returns the kubeconfig flags of the arguments. The
+// context modified by config set-context is targeted to complete its namespace.
+func GetConfigOverrides(cmdVerb string, args []string) clusterconfig.ConfigOverrides {
+	overrides := parse.ParseConfigOverrides(args)
+	rule, ruleArgs, ok := GetVerbRule(cmdVerb, args)
+	if !ok || !rule.Contexts {
+		return overrides
+	}
+	if positionalArgs := getCompletedPositionalArgs(cmdVerb, ruleArgs); len(positionalArgs) > 0 {
+		overrides.Context = positionalArgs[0]
+	}
+	return overrides
+}
+
+// getContextCompletion lists the kubeconfig contexts with whether their
+// resources are already cached
+func getContextCompletion(fetchConfig *fetcher.Fetcher) []string {
+	comps := []string{}
+	for _, kubeContext := range fetchConfig.GetKubeContexts() {
+		comps = append(comps, util.DumpLines([]string{
+			kubeContext.Name,
+			kubeContext.Cluster,
+			kubeContext.User,
+			kubeContext.Namespace,
+			strconv.FormatBool(kubeContext.Current),
+			strconv.FormatBool(fetchConfig.HasWarmCache(kubeContext.Name)),
+		})...)
+	}
+	return comps
+}
+
+func processContext(fetchConfig *fetcher.Fetcher) *CompletionResult {
+	return &CompletionResult{
+		Cluster:     fetchConfig.GetContext(),
+		Header:      resources.ContextHeader,
+		Completions: getContextCompletion(fetchConfig),
+	}
+}
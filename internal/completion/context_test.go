@@ -0,0 +1,70 @@
+package completion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher/fetchertest"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/clusterconfig"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestFetcherWithKubeconfig(t *testing.T) *fetcher.Fetcher {
+	t.Setenv("KUBECONFIG", "testdata/kubeconfig")
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	require.NoError(t, fetchConfig.LoadClusterConfig())
+	return fetchConfig
+}
+
+func TestIsContextCompletion(t *testing.T) {
+	testDatas := []struct {
+		verb              string
+		args              []string
+		contextCompletion bool
+	}{
+		{"", []string{"--context", " "}, true},
+		{"", []string{"--context="}, true},
+		{"get", []string{"pods", "--context", " "}, true},
+		{"config", []string{"use-context", " "}, true},
+		{"config", []string{"use", "mini"}, true},
+		{"config", []string{"set-context", " "}, true},
+		{"config", []string{" "}, false},
+		{"config", []string{"use-context", "minikube", " "}, false},
+		{"config", []string{"set-context", "--current", " "}, false},
+		{"config", []string{"set-context", "--current", "--namespace", " "}, false},
+		{"get", []string{"pods", " "}, false},
+	}
+	for _, testData := range testDatas {
+		assert.Equal(t, testData.contextCompletion, IsContextCompletion(testData.verb, testData.args), "verb: %s, args: %s", testData.verb, testData.args)
+	}
+}
+
+func TestContextCompletion(t *testing.T) {
+	fetchConfig := getTestFetcherWithKubeconfig(t)
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "config", []string{"use-context", " "})
+	require.NoError(t, err)
+	assert.Equal(t, resources.ContextHeader, completionResults.Header)
+	assert.Equal(t, "minikube", completionResults.Cluster)
+	assert.Equal(t, []string{
+		"minikube\tminikube\tminikube\tdefault\ttrue\ttrue",
+		"prod-eu\tprod-eu\tadmin\tpayments\tfalse\tfalse",
+	}, completionResults.Completions)
+}
+
+func TestSetContextNamespaceCompletion(t *testing.T) {
+	fetchConfig := getTestFetcherWithKubeconfig(t)
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "config", []string{"set-context", "--current", "--namespace", " "})
+	require.NoError(t, err)
+	assert.Equal(t, resources.ResourceToHeader(resources.ResourceTypeNamespace), completionResults.Header)
+	assert.NotEmpty(t, completionResults.Completions)
+
+	overrides := GetConfigOverrides("config", []string{"set-context", "prod-eu", "--namespace", " "})
+	assert.Equal(t, clusterconfig.ConfigOverrides{Context: "prod-eu"}, overrides)
+	overrides = GetConfigOverrides("config", []string{"set-context", "--current", "--namespace", " "})
+	assert.Equal(t, clusterconfig.ConfigOverrides{}, overrides)
+	overrides = GetConfigOverrides("get", []string{"--context", "prod-eu", "pods", " "})
+	assert.Equal(t, clusterconfig.ConfigOverrides{Context: "prod-eu"}, overrides)
+}
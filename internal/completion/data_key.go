@@ -0,0 +1,45 @@
+package completion
+
+import (
+	"context"
+	"sort"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/parse"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// getDataKeyCompletion lists the data keys of the secret or configMap
+// provided in the arguments, or the keys of all of them if no name was provided
+func getDataKeyCompletion(ctx context.Context, args []string, namespace *string,
+	fetchConfig *fetcher.Fetcher) ([]string, error) {
+	resourceType, name, ok := parse.ParseDataKeyTarget(args)
+	if !ok {
+		return nil, parse.UnmanagedFlagError("no secret or configmap to complete keys from")
+	}
+	logrus.Debugf("Completing data keys of %s '%s', namespace %v", resourceType, name, namespace)
+	k8sResources, err := fetchConfig.GetResources(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	comps := []string{}
+	for _, k8sResource := range k8sResources {
+		dataKeysGetter, ok := k8sResource.(resources.DataKeysGetter)
+		if !ok {
+			continue
+		}
+		if namespace != nil && *namespace != dataKeysGetter.GetNamespace() {
+			continue
+		}
+		if name != "" && name != dataKeysGetter.GetName() {
+			continue
+		}
+		for _, key := range dataKeysGetter.GetDataKeys() {
+			comps = append(comps, util.DumpLines([]string{dataKeysGetter.GetNamespace(), dataKeysGetter.GetName(), key})...)
+		}
+	}
+	sort.Strings(comps)
+	return comps, nil
+}
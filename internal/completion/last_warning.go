@@ -0,0 +1,29 @@
+package completion
+
+import (
+	"context"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/sirupsen/logrus"
+)
+
+// setLastWarnings joins the most recent warning event of each resource.
+// Events are optional, the resources are left untouched if they can't be fetched.
+func setLastWarnings(ctx context.Context, r resources.ResourceType,
+	k8sResources map[string]resources.K8sResource, fetchConfig *fetcher.Fetcher) {
+	if r != resources.ResourceTypePod && r != resources.ResourceTypeNode {
+		return
+	}
+	events, err := fetchConfig.GetResources(ctx, resources.ResourceTypeEvent)
+	if err != nil {
+		logrus.Infof("Couldn't fetch events, skipping last warnings: %s", err)
+		return
+	}
+	lastWarnings := resources.GetLastWarnings(events)
+	for _, k8sResource := range k8sResources {
+		if setter, ok := k8sResource.(resources.LastWarningSetter); ok {
+			setter.SetLastWarning(lastWarnings)
+		}
+	}
+}
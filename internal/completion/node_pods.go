@@ -0,0 +1,48 @@
+package completion
+
+import (
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/sirupsen/logrus"
+)
+
+// getPodCountPerNode counts the pods scheduled on each node, finished pods are ignored
+func getPodCountPerNode(pods map[string]resources.K8sResource) map[string]int {
+	podCounts := map[string]int{}
+	for _, r := range pods {
+		pod, ok := r.(*resources.Pod)
+		if !ok || pod.NodeName == "" {
+			continue
+		}
+		if pod.Phase == "Succeeded" || pod.Phase == "Failed" {
+			continue
+		}
+		podCounts[pod.NodeName]++
+	}
+	return podCounts
+}
+
+// setNodePodCounts joins the number of pods scheduled on each node.
+// Fetching all pods would slow down the node completion, the pod count is
+// only joined when pods are available locally or in the cache.
+func setNodePodCounts(r resources.ResourceType,
+	k8sResources map[string]resources.K8sResource, fetchConfig *fetcher.Fetcher) {
+	if r != resources.ResourceTypeNode {
+		return
+	}
+	pods, err := fetchConfig.GetCachedResources(resources.ResourceTypePod)
+	if err != nil {
+		logrus.Infof("Couldn't load pods, skipping pod count: %s", err)
+		return
+	}
+	if pods == nil {
+		logrus.Infof("Pods are not cached, skipping pod count")
+		return
+	}
+	podCounts := getPodCountPerNode(pods)
+	for _, k8sResource := range k8sResources {
+		if node, ok := k8sResource.(*resources.Node); ok {
+			node.SetPodCount(podCounts)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package completion
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/parse"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// portForwardTargets are the resource types proposed as port-forward target
+// with the prefix used in the completion
+var portForwardTargets = []struct {
+	resourceType resources.ResourceType
+	prefix       string
+}{
+	{resources.ResourceTypeService, "svc"},
+	{resources.ResourceTypePod, "pod"},
+	{resources.ResourceTypeDeployment, "deploy"},
+}
+
+// isLocalPortFree checks if the local port can be listened on
+var isLocalPortFree = func(port int) bool {
+	l, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", port))
+	if err != nil {
+		return false
+	}
+	l.Close()
+	return true
+}
+
+// getLocalPort returns a free local port to forward the remote port to.
+// Privileged ports are shifted by 8000, like 80 to 8080.
+// 0 is returned if no free port was found.
+func getLocalPort(port int32, usedPorts map[int]bool) int {
+	base := int(port)
+	if base < 1024 {
+		base += 8000
+	}
+	for p := base; p < base+100 && p <= 65535; p++ {
+		if usedPorts[p] || !isLocalPortFree(p) {
+			continue
+		}
+		usedPorts[p] = true
+		return p
+	}
+	return 0
+}
+
+// isForwardable returns false for the protocols not supported by port-forward
+func isForwardable(port resources.PortInfo) bool {
+	return port.Protocol == "" || port.Protocol == "TCP"
+}
+
+func portsSummary(ports []resources.PortInfo) string {
+	res := make([]string, 0, len(ports))
+	for _, p := range ports {
+		if p.Name != "" {
+			res = append(res, fmt.Sprintf("%s:%d", p.Name, p.Port))
+		} else {
+			res = append(res, strconv.Itoa(int(p.Port)))
+		}
+	}
+	return util.JoinSlicesOrNone(res, ",")
+}
+
+// getPortForwardTargetCompletion lists the services, pods and deployments
+// which can be port-forwarded
+func getPortForwardTargetCompletion(ctx context.Context, namespace *string,
+	fetchConfig *fetcher.Fetcher) ([]string, error) {
+	comps := []string{}
+	var lastErr error
+	fetched := 0
+	for _, target := range portForwardTargets {
+		k8sResources, err := fetchConfig.GetResources(ctx, target.resourceType)
+		if err != nil {
+			logrus.Infof("Error fetching %s, skipping them from port-forward targets: %s", target.resourceType, err)
+			lastErr = err
+			continue
+		}
+		fetched++
+		for _, k8sResource := range k8sResources {
+			portsGetter, ok := k8sResource.(resources.PortsGetter)
+			if !ok {
+				continue
+			}
+			if namespace != nil && *namespace != portsGetter.GetNamespace() {
+				continue
+			}
+			comps = append(comps, util.DumpLines([]string{
+				portsGetter.GetNamespace(),
+				fmt.Sprintf("%s/%s", target.prefix, portsGetter.GetName()),
+				portsSummary(portsGetter.GetPorts()),
+			})...)
+		}
+	}
+	if fetched == 0 {
+		return nil, lastErr
+	}
+	return comps, nil
+}
+
+// getPortCompletion lists the ports of the port-forward target, each with
+// a free local port to forward to
+func getPortCompletion(ctx context.Context, resourceType resources.ResourceType, name string,
+	namespace *string, fetchConfig *fetcher.Fetcher) ([]string, error) {
+	k8sResources, err := fetchConfig.GetResources(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	usedPorts := map[int]bool{}
+	comps := []string{}
+	for _, k8sResource := range k8sResources {
+		portsGetter, ok := k8sResource.(resources.PortsGetter)
+		if !ok {
+			continue
+		}
+		if namespace != nil && *namespace != portsGetter.GetNamespace() {
+			continue
+		}
+		if name != portsGetter.GetName() {
+			continue
+		}
+		target := fmt.Sprintf("%s/%s", strings.TrimSuffix(resourceType.String(), "s"), name)
+		for _, port := range portsGetter.GetPorts() {
+			if !isForwardable(port) {
+				continue
+			}
+			remote := port.Name
+			if remote == "" {
+				remote = strconv.Itoa(int(port.Port))
+			}
+			forward := fmt.Sprintf(":%s", remote)
+			if localPort := getLocalPort(port.Port, usedPorts); localPort > 0 {
+				forward = fmt.Sprintf("%d%s", localPort, forward)
+			}
+			comps = append(comps, util.DumpLines([]string{
+				portsGetter.GetNamespace(),
+				target,
+				forward,
+				port.Name,
+				strconv.Itoa(int(port.Port)),
+				port.Protocol,
+				port.Target,
+			})...)
+		}
+	}
+	return comps, nil
+}
+
+// processPortForward completes the target of a port-forward command, then
+// the ports of the target
+func processPortForward(ctx context.Context, fetchConfig *fetcher.Fetcher, args []string) (*CompletionResult, error) {
+	var err error
+	namespace := parse.ParseNamespaceFromArgs(args)
+	completionResult := &CompletionResult{Cluster: fetchConfig.GetContext()}
+	resourceType, name, ok := parse.ParsePortForwardTarget(args)
+	if !ok {
+		completionResult.Header = resources.PortForwardTargetHeader
+		completionResult.Completions, err = getPortForwardTargetCompletion(ctx, namespace, fetchConfig)
+		sort.Strings(completionResult.Completions)
+		return completionResult, err
+	}
+	logrus.Debugf("Completing ports of %s %s", resourceType, name)
+	completionResult.Header = resources.PortHeader
+	completionResult.Completions, err = getPortCompletion(ctx, resourceType, name, namespace, fetchConfig)
+	return completionResult, err
+}
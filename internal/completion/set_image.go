@@ -0,0 +1,47 @@
+package completion
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/parse"
+	"github.com/sirupsen/logrus"
+)
+
+// processSetImage completes the template containers of the workload of a set image command
+func processSetImage(ctx context.Context, fetchConfig *fetcher.Fetcher, args []string) (*CompletionResult, error) {
+	resourceType, name, ok := parse.ParseSetImageTarget(args)
+	if !ok {
+		return nil, resources.UnknownResourceError{ResourceStr: strings.Join(args, " ")}
+	}
+	logrus.Debugf("Completing containers of %s %s", resourceType, name)
+	k8sResources, err := fetchConfig.GetResources(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	namespace := parse.ParseNamespaceFromArgs(args)
+	comps := []string{}
+	for _, k8sResource := range k8sResources {
+		templateGetter, ok := k8sResource.(resources.TemplateGetter)
+		if !ok {
+			continue
+		}
+		if namespace != nil && *namespace != templateGetter.GetNamespace() {
+			continue
+		}
+		if name != "" && name != templateGetter.GetName() {
+			continue
+		}
+		comps = append(comps, templateGetter.GetTemplate().ContainersToStrings(
+			templateGetter.GetNamespace(), templateGetter.GetName())...)
+	}
+	sort.Strings(comps)
+	return &CompletionResult{
+		Cluster:     fetchConfig.GetContext(),
+		Header:      resources.TemplateContainerHeader,
+		Completions: comps,
+	}, nil
+}
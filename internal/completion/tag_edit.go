@@ -0,0 +1,151 @@
+package completion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/parse"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// tagEditVerbs maps the verbs editing tags to the tag type they edit
+var tagEditVerbs = map[string]TagType{
+	"annotate": TagTypeAnnotation,
+	"label":    TagTypeLabel,
+}
+
+// getTargets returns the resources matching the name.
+// The namespace is only matched if provided.
+func getTargets(k8sResources map[string]resources.K8sResource, name string,
+	namespace *string) []resources.K8sResource {
+	targets := []resources.K8sResource{}
+	for _, k8sResource := range k8sResources {
+		if namespace != nil && *namespace != k8sResource.GetNamespace() {
+			continue
+		}
+		nameGetter, ok := k8sResource.(interface{ GetName() string })
+		if !ok || nameGetter.GetName() != name {
+			continue
+		}
+		targets = append(targets, k8sResource)
+	}
+	return targets
+}
+
+func sortedKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// getAnnotationEditCompletion proposes to overwrite or remove the
+// annotations of the resource
+func getAnnotationEditCompletion(tags map[string]string) []string {
+	comps := []string{}
+	for _, k := range sortedKeys(tags) {
+		value := util.TruncateString(tags[k], 300)
+		comps = append(comps, util.DumpLines([]string{fmt.Sprintf("%s=", k), resources.TagEditOverwrite, value})...)
+		comps = append(comps, util.DumpLines([]string{fmt.Sprintf("%s-", k), resources.TagEditRemove, value})...)
+	}
+	return comps
+}
+
+// getSiblingKeyOccurrences counts the label keys of the resources sharing
+// the namespaces of the targets. Labels managed by controllers are ignored.
+func getSiblingKeyOccurrences(k8sResources map[string]resources.K8sResource,
+	targets []resources.K8sResource) map[TagResourceKey]int {
+	namespaces := map[string]bool{}
+	for _, target := range targets {
+		namespaces[target.GetNamespace()] = true
+	}
+	occurrences := map[TagResourceKey]int{}
+	for _, k8sResource := range k8sResources {
+		if !namespaces[k8sResource.GetNamespace()] {
+			continue
+		}
+		for k := range k8sResource.GetLabels() {
+			if _, ok := resources.ExcludedLabels[k]; ok {
+				continue
+			}
+			occurrences[TagResourceKey{k8sResource.GetNamespace(), k}]++
+		}
+	}
+	return occurrences
+}
+
+// getLabelEditCompletion proposes to overwrite or remove the labels of the
+// resource, then to add the label keys used by its siblings, most used first
+func getLabelEditCompletion(tags map[string]string, siblingOccurrences map[TagResourceKey]int) []string {
+	keyOccurrences := map[string]int{}
+	siblingKeys := make(TagResourcePairList, 0)
+	for k, occurrence := range siblingOccurrences {
+		keyOccurrences[k.Value] += occurrence
+		if _, ok := tags[k.Value]; !ok {
+			siblingKeys = append(siblingKeys, TagResourcePair{k, occurrence})
+		}
+	}
+	sort.Sort(siblingKeys)
+
+	comps := []string{}
+	for _, k := range sortedKeys(tags) {
+		occurrences := strconv.Itoa(keyOccurrences[k])
+		comps = append(comps, util.DumpLines([]string{fmt.Sprintf("%s=%s", k, tags[k]), resources.TagEditOverwrite, occurrences})...)
+		comps = append(comps, util.DumpLines([]string{fmt.Sprintf("%s-", k), resources.TagEditRemove, occurrences})...)
+	}
+	added := map[string]bool{}
+	for _, siblingKey := range siblingKeys {
+		// The same key can be used in multiple namespaces
+		if added[siblingKey.Key.Value] {
+			continue
+		}
+		added[siblingKey.Key.Value] = true
+		comps = append(comps, util.DumpLines([]string{fmt.Sprintf("%s=", siblingKey.Key.Value),
+			resources.TagEditAdd, strconv.Itoa(keyOccurrences[siblingKey.Key.Value])})...)
+	}
+	return comps
+}
+
+// processTagEdit completes the edition of the labels or annotations once
+// the target resource is provided. A nil result means the target isn't
+// provided yet.
+func processTagEdit(ctx context.Context, fetchConfig *fetcher.Fetcher, cmdVerb string,
+	args []string) (*CompletionResult, error) {
+	tagType, ok := tagEditVerbs[cmdVerb]
+	if !ok || parse.CheckFlagManaged(cmdVerb, args) != parse.FlagNone {
+		return nil, nil
+	}
+	resourceType, name, ok := parse.ParseTagEditTarget(cmdVerb, args)
+	if !ok {
+		return nil, nil
+	}
+	logrus.Debugf("Completing %s edition of %s %s", cmdVerb, resourceType, name)
+	k8sResources, err := fetchConfig.GetResources(ctx, resourceType)
+	if err != nil {
+		return nil, err
+	}
+	namespace := parse.ParseNamespaceFromArgs(args)
+	targets := getTargets(k8sResources, name, namespace)
+	tags := map[string]string{}
+	for _, target := range targets {
+		for k, v := range getTags(target, tagType) {
+			tags[k] = v
+		}
+	}
+	completionResult := &CompletionResult{Cluster: fetchConfig.GetContext()}
+	if tagType == TagTypeLabel {
+		completionResult.Header = resources.LabelEditHeader
+		completionResult.Completions = getLabelEditCompletion(tags, getSiblingKeyOccurrences(k8sResources, targets))
+	} else {
+		completionResult.Header = resources.AnnotationEditHeader
+		completionResult.Completions = getAnnotationEditCompletion(tags)
+	}
+	return completionResult, nil
+}
@@ -16,8 +16,19 @@ type TagType int64
 const (
 	TagTypeLabel TagType = iota
 	TagTypeFieldSelector
+	TagTypeAnnotation
 )
 
+func getTags(resource resources.K8sResource, tagType TagType) map[string]string {
+	switch tagType {
+	case TagTypeLabel:
+		return resource.GetLabels()
+	case TagTypeAnnotation:
+		return resource.GetAnnotations()
+	}
+	return resource.GetFieldSelectors()
+}
+
 type TagResourceKey struct {
 	Namespace string
 	Value     string
@@ -62,13 +73,7 @@ func getTagResourceOccurrences(ctx context.Context, r resources.ResourceType, na
 	resourceKeyToOccurrences := make(map[TagResourceKey]int, 0)
 	for _, resource := range resources {
 		if namespace == nil || *namespace == resource.GetNamespace() {
-			var tagResource map[string]string
-			if tagType == TagTypeLabel {
-				tagResource = resource.GetLabels()
-			} else {
-				tagResource = resource.GetFieldSelectors()
-			}
-			for k, v := range tagResource {
+			for k, v := range getTags(resource, tagType) {
 				valueStr := fmt.Sprintf("%s=%s", k, v)
 				valueKey := TagResourceKey{resource.GetNamespace(), valueStr}
 				resourceKeyToOccurrences[valueKey] += 1
@@ -99,6 +104,8 @@ func GetTagResourceCompletion(ctx context.Context, r resources.ResourceType, nam
 	labelHeaders := []string{"Occurrences"}
 	if tagType == TagTypeFieldSelector {
 		labelHeaders = append([]string{"FieldSelector"}, labelHeaders...)
+	} else if tagType == TagTypeAnnotation {
+		labelHeaders = append([]string{"Annotation"}, labelHeaders...)
 	} else {
 		labelHeaders = append([]string{"Label"}, labelHeaders...)
 	}
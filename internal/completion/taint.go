@@ -0,0 +1,122 @@
+package completion
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/parse"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// taintRemoval returns the removal form of a taint, key:Effect-
+func taintRemoval(taint string) string {
+	idx := strings.LastIndex(taint, ":")
+	if idx < 0 {
+		return fmt.Sprintf("%s-", taint)
+	}
+	key := strings.SplitN(taint[:idx], "=", 2)[0]
+	return fmt.Sprintf("%s:%s-", key, taint[idx+1:])
+}
+
+// isValidTaint filters the tolerations which can't be expressed as a taint,
+// like tolerations matching all keys or all effects
+func isValidTaint(toleration string) bool {
+	idx := strings.LastIndex(toleration, ":")
+	return idx > 0 && idx < len(toleration)-1
+}
+
+// countTaints counts the taints of the nodes and the tolerations of the pods
+func countTaints(nodes map[string]resources.K8sResource, pods map[string]resources.K8sResource) (map[TagResourceKey]int, map[TagResourceKey]int) {
+	nodeTaints := make(map[TagResourceKey]int, 0)
+	for _, k8sResource := range nodes {
+		node, ok := k8sResource.(*resources.Node)
+		if !ok {
+			continue
+		}
+		for _, taint := range node.Taints {
+			nodeTaints[TagResourceKey{"", taint}]++
+		}
+	}
+	tolerations := make(map[TagResourceKey]int, 0)
+	for _, k8sResource := range pods {
+		pod, ok := k8sResource.(*resources.Pod)
+		if !ok {
+			continue
+		}
+		for _, toleration := range pod.Tolerations {
+			if isValidTaint(toleration) {
+				tolerations[TagResourceKey{"", toleration}]++
+			}
+		}
+	}
+	return nodeTaints, tolerations
+}
+
+// getTaintEditCompletion proposes to remove the taints of the node, then to
+// add the taints seen on other nodes, then the taints tolerated by pods.
+// Both are ordered by occurrences.
+func getTaintEditCompletion(node *resources.Node, nodes map[string]resources.K8sResource,
+	pods map[string]resources.K8sResource) []string {
+	comps := []string{}
+	for _, taint := range node.Taints {
+		comps = append(comps, util.DumpLines([]string{taintRemoval(taint), resources.TagEditRemove, "1", "node"})...)
+	}
+	nodeTaints, tolerations := countTaints(nodes, pods)
+	added := util.StringSliceToSet(node.Taints)
+	for _, source := range []struct {
+		name        string
+		occurrences map[TagResourceKey]int
+	}{{"nodes", nodeTaints}, {"tolerations", tolerations}} {
+		pairs := make(TagResourcePairList, 0)
+		for k, occurrence := range source.occurrences {
+			pairs = append(pairs, TagResourcePair{k, occurrence})
+		}
+		sort.Sort(pairs)
+		for _, pair := range pairs {
+			if added[pair.Key.Value] {
+				continue
+			}
+			added[pair.Key.Value] = true
+			comps = append(comps, util.DumpLines([]string{pair.Key.Value, resources.TagEditAdd,
+				strconv.Itoa(pair.Occurrences), source.name})...)
+		}
+	}
+	return comps
+}
+
+// processTaint completes the taints once the node is provided.
+// A nil result means the node isn't provided yet.
+func processTaint(ctx context.Context, fetchConfig *fetcher.Fetcher, args []string) (*CompletionResult, error) {
+	if parse.CheckFlagManaged("taint", args) != parse.FlagNone {
+		return nil, nil
+	}
+	resourceType, name, ok := parse.ParseTagEditTarget("taint", args)
+	if !ok || resourceType != resources.ResourceTypeNode {
+		return nil, nil
+	}
+	logrus.Debugf("Completing taints of node %s", name)
+	nodes, err := fetchConfig.GetResources(ctx, resources.ResourceTypeNode)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := fetchConfig.GetResources(ctx, resources.ResourceTypePod)
+	if err != nil {
+		logrus.Infof("Error fetching pods, skipping tolerated taints: %s", err)
+	}
+	completionResult := &CompletionResult{
+		Cluster: fetchConfig.GetContext(),
+		Header:  resources.TaintEditHeader,
+	}
+	for _, target := range getTargets(nodes, name, nil) {
+		if node, ok := target.(*resources.Node); ok {
+			completionResult.Completions = getTaintEditCompletion(node, nodes, pods)
+		}
+	}
+	return completionResult, nil
+}
@@ -0,0 +1,66 @@
+package completion
+
+import (
+	"context"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/parse"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+type nameGetter interface {
+	GetName() string
+}
+
+// GetResourceTypes returns the resource types to complete when multiple
+// types are provided, either as a comma separated list like pods,svc or as
+// type/name arguments like pod/a svc/b. Types not accepted by the verb are
+// dropped.
+func GetResourceTypes(cmdVerb string, args []string) []resources.ResourceType {
+	if len(args) == 0 || parse.CheckFlagManaged(cmdVerb, args) != parse.FlagNone {
+		return nil
+	}
+	rule, ruleArgs, ok := GetVerbRule(cmdVerb, args)
+	if !ok || rule.SubVerbs != nil || rule.TypeOnly || rule.ImplicitType != resources.ResourceTypeApiResource {
+		return nil
+	}
+	lastArg := ruleArgs[len(ruleArgs)-1]
+	if _, _, ok := resources.ParseTypeName(lastArg); ok || resources.IsResourceTypeListPrefix(lastArg) {
+		return nil
+	}
+	res := []resources.ResourceType{}
+	for _, resourceType := range resources.GetResourceTypes(getCompletedPositionalArgs(cmdVerb, ruleArgs)) {
+		if rule.IsAllowed(resourceType) {
+			res = append(res, resourceType)
+		}
+	}
+	return res
+}
+
+// getMultiResourceCompletion merges the resources of multiple types with
+// their type as first column
+func getMultiResourceCompletion(ctx context.Context, resourceTypes []resources.ResourceType,
+	namespace *string, fetchConfig *fetcher.Fetcher) ([]string, error) {
+	comps := []string{}
+	for _, resourceType := range resourceTypes {
+		k8sResources, err := fetchConfig.GetResources(ctx, resourceType)
+		if err != nil {
+			logrus.Infof("Error fetching %s: %v", resourceType, err)
+			continue
+		}
+		for _, k8sResource := range k8sResources {
+			named, ok := k8sResource.(nameGetter)
+			if !ok {
+				continue
+			}
+			if resourceType.IsNamespaced() && namespace != nil && *namespace != k8sResource.GetNamespace() {
+				continue
+			}
+			comps = append(comps, util.DumpLines([]string{resourceType.String(),
+				k8sResource.GetNamespace(), named.GetName()})...)
+		}
+	}
+	return comps, nil
+}
@@ -0,0 +1,49 @@
+package completion
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher/fetchertest"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypeNameCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "describe", []string{"deploy/"})
+	require.NoError(t, err)
+	assert.Equal(t, resources.ResourceToHeader(resources.ResourceTypeDeployment), completionResults.Header)
+	require.NotEmpty(t, completionResults.Completions)
+
+	completionResults, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "logs", []string{"deployment/"})
+	require.NoError(t, err)
+	assert.Equal(t, resources.ResourceToHeader(resources.ResourceTypeDeployment), completionResults.Header)
+
+	_, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "taint", []string{"pods/"})
+	require.ErrorAs(t, err, &resources.UnknownResourceError{})
+
+	assert.Equal(t, "co", ExtractQueryFromArgs([]string{"deploy/co"}))
+	assert.Equal(t, "se", ExtractQueryFromArgs([]string{"pods,se"}))
+}
+
+func TestMultiResourceCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	for _, args := range [][]string{{"pods,svc", " "}, {"pod/a", "svc/b", " "}} {
+		completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "get", args)
+		require.NoError(t, err)
+		assert.Equal(t, resources.MultiResourceHeader, completionResults.Header)
+		assert.Contains(t, completionResults.Completions, "services\tkube-system\tkube-dns")
+		assert.Contains(t, completionResults.Completions, "pods\tkube-system\tcoredns-6d4b75cb6d-m6m4q")
+	}
+
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "get", []string{"pods,svc", "-n", "default", " "})
+	require.NoError(t, err)
+	assert.NotContains(t, completionResults.Completions, "services\tkube-system\tkube-dns")
+	assert.Contains(t, completionResults.Completions, "services\tdefault\tkubernetes")
+
+	completionResults, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "get", []string{"pods,"})
+	require.NoError(t, err)
+	assert.Equal(t, resources.ResourceToHeader(resources.ResourceTypeApiResource), completionResults.Header)
+}
@@ -0,0 +1,296 @@
+package completion
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/parse"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	"github.com/sirupsen/logrus"
+)
+
+// VerbRule describes how the arguments of a kubectl verb are completed
+type VerbRule struct {
+	// ResourceTypes are the resource types accepted by the verb, all types are accepted if empty
+	ResourceTypes []resources.ResourceType
+	// APIVerb is the api verb the completed resource types need to support, like delete
+	APIVerb string
+	// Subresource is the subresource the completed resource types need to have, like scale
+	Subresource string
+	// ImplicitType is the resource type completed without providing it, like pods for exec
+	ImplicitType resources.ResourceType
+	// SkipArgs is the number of positional arguments preceding the resource type, like the verb of auth can-i
+	SkipArgs int
+	// TypeOnly verbs only accept a resource type, like explain
+	TypeOnly bool
+	// Contexts verbs complete the contexts of the kubeconfig, like config use-context
+	Contexts bool
+	// MultiSelect verbs accept multiple resource names, like delete
+	MultiSelect bool
+	// Aliases are the alternative names of a subverb
+	Aliases []string
+	// SubVerbs are the subcommands of the verb, like rollout restart
+	SubVerbs map[string]*VerbRule
+}
+
+var workloadTypes = []resources.ResourceType{resources.ResourceTypeDeployment,
+	resources.ResourceTypeDaemonSet, resources.ResourceTypeStatefulSet}
+
+var podSpecTypes = []resources.ResourceType{resources.ResourceTypePod,
+	resources.ResourceTypeDeployment, resources.ResourceTypeDaemonSet, resources.ResourceTypeStatefulSet,
+	resources.ResourceTypeReplicaSet, resources.ResourceTypeCronJob, resources.ResourceTypeJob}
+
+var podRule = &VerbRule{ImplicitType: resources.ResourceTypePod}
+var nodeRule = &VerbRule{ImplicitType: resources.ResourceTypeNode}
+var patchRule = &VerbRule{APIVerb: "patch"}
+var tagRule = &VerbRule{APIVerb: "patch", MultiSelect: true}
+
+// verbRules are the kubectl verbs supported by the completion
+var verbRules = map[string]*VerbRule{
+	"get":          {APIVerb: "get", MultiSelect: true},
+	"describe":     {APIVerb: "get"},
+	"delete":       {APIVerb: "delete", MultiSelect: true},
+	"edit":         patchRule,
+	"label":        tagRule,
+	"annotate":     tagRule,
+	"patch":        patchRule,
+	"explain":      {TypeOnly: true},
+	"exec":         podRule,
+	"logs":         podRule,
+	"attach":       podRule,
+	"cp":           podRule,
+	"port-forward": podRule,
+	"debug": {ImplicitType: resources.ResourceTypePod,
+		ResourceTypes: []resources.ResourceType{resources.ResourceTypePod, resources.ResourceTypeNode}},
+	"drain":    nodeRule,
+	"cordon":   nodeRule,
+	"uncordon": nodeRule,
+	"taint":    {ResourceTypes: []resources.ResourceType{resources.ResourceTypeNode}},
+	"scale": {Subresource: "scale", ResourceTypes: []resources.ResourceType{resources.ResourceTypeDeployment,
+		resources.ResourceTypeReplicaSet, resources.ResourceTypeStatefulSet}},
+	"rollout": {SubVerbs: map[string]*VerbRule{
+		"restart": {ResourceTypes: workloadTypes},
+		"status":  {ResourceTypes: workloadTypes},
+		"undo":    {ResourceTypes: workloadTypes},
+		"history": {ResourceTypes: workloadTypes},
+		"pause":   {ResourceTypes: []resources.ResourceType{resources.ResourceTypeDeployment}},
+		"resume":  {ResourceTypes: []resources.ResourceType{resources.ResourceTypeDeployment}},
+	}},
+	"set": {SubVerbs: map[string]*VerbRule{
+		"image": {ResourceTypes: []resources.ResourceType{resources.ResourceTypeDeployment,
+			resources.ResourceTypeDaemonSet, resources.ResourceTypeStatefulSet, resources.ResourceTypeReplicaSet}},
+		"env":       {ResourceTypes: podSpecTypes},
+		"resources": {ResourceTypes: podSpecTypes},
+	}},
+	"top": {SubVerbs: map[string]*VerbRule{
+		"pod":  {ImplicitType: resources.ResourceTypePod, Aliases: []string{"pods", "po"}},
+		"node": {ImplicitType: resources.ResourceTypeNode, Aliases: []string{"nodes", "no"}},
+	}},
+	"auth": {SubVerbs: map[string]*VerbRule{
+		"can-i": {SkipArgs: 1, TypeOnly: true},
+	}},
+	"config": {SubVerbs: map[string]*VerbRule{
+		"use-context": {Contexts: true, Aliases: []string{"use"}},
+		"set-context": {Contexts: true},
+	}},
+}
+
+// IsSupportedVerb returns true if the verb is completed by kubectl-fzf
+func IsSupportedVerb(cmdVerb string) bool {
+	_, ok := verbRules[cmdVerb]
+	return ok
+}
+
+// getCompletedPositionalArgs returns the positional arguments, ignoring the
+// last argument which is the one being completed
+func getCompletedPositionalArgs(cmdVerb string, args []string) []string {
+	if len(args) == 0 {
+		return nil
+	}
+	return parse.GetPositionalArgs(cmdVerb, args[:len(args)-1])
+}
+
+func (r *VerbRule) getSubVerb(subVerb string) *VerbRule {
+	for name, rule := range r.SubVerbs {
+		if name == subVerb || util.IsStringIn(subVerb, rule.Aliases) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// GetVerbRule returns the rule of the verb. When the verb has subcommands,
+// the rule of the subcommand is returned with the subcommand removed from
+// the arguments. The verb's own rule is returned if the subcommand isn't
+// provided yet.
+func GetVerbRule(cmdVerb string, args []string) (*VerbRule, []string, bool) {
+	rule, ok := verbRules[cmdVerb]
+	if !ok {
+		return nil, args, false
+	}
+	if rule.SubVerbs == nil {
+		return rule, args, true
+	}
+	positionalArgs := getCompletedPositionalArgs(cmdVerb, args)
+	if len(positionalArgs) == 0 {
+		return rule, args, true
+	}
+	subVerbRule := rule.getSubVerb(positionalArgs[0])
+	if subVerbRule == nil {
+		logrus.Infof("Unknown subcommand %s of %s", positionalArgs[0], cmdVerb)
+		return nil, args, false
+	}
+	remainingArgs := []string{}
+	removed := false
+	for _, arg := range args {
+		if !removed && arg == positionalArgs[0] {
+			removed = true
+			continue
+		}
+		remainingArgs = append(remainingArgs, arg)
+	}
+	return subVerbRule, remainingArgs, true
+}
+
+// IsSubVerbCompletion returns true if the subcommand of the verb needs to be completed
+func IsSubVerbCompletion(cmdVerb string, args []string) bool {
+	rule, _, ok := GetVerbRule(cmdVerb, args)
+	return ok && rule.SubVerbs != nil
+}
+
+// IsMultiSelect returns true if multiple resource names can be selected
+// for the arguments of the verb
+func IsMultiSelect(cmdVerb string, args []string) bool {
+	rule, _, ok := GetVerbRule(cmdVerb, args)
+	if !ok || !rule.MultiSelect {
+		return false
+	}
+	resourceType, flagCompletion, err := ParseFlagAndResources(cmdVerb, args)
+	return err == nil && flagCompletion == parse.FlagNone && resourceType != resources.ResourceTypeApiResource
+}
+
+// IsAllowed returns true if the resource type is accepted by the verb
+func (r *VerbRule) IsAllowed(resourceType resources.ResourceType) bool {
+	if len(r.ResourceTypes) == 0 {
+		return true
+	}
+	for _, allowedType := range r.ResourceTypes {
+		if allowedType == resourceType {
+			return true
+		}
+	}
+	return false
+}
+
+// GetResourceType returns the resource type to complete for the arguments
+// of the verb, with the subcommand already removed
+func (r *VerbRule) GetResourceType(cmdVerb string, args []string) resources.ResourceType {
+	if r.Contexts {
+		return resources.ResourceTypeUnknown
+	}
+	if len(args) > 0 && !r.TypeOnly {
+		// The name of a type/name argument is being completed, like logs deploy/<TAB>
+		if resourceType, _, ok := resources.ParseTypeName(args[len(args)-1]); ok {
+			if !r.IsAllowed(resourceType) {
+				logrus.Infof("Resource type %s is not accepted by %s", resourceType, cmdVerb)
+				return resources.ResourceTypeUnknown
+			}
+			return resourceType
+		}
+	}
+	if r.ImplicitType != resources.ResourceTypeApiResource {
+		return r.ImplicitType
+	}
+	positionalArgs := getCompletedPositionalArgs(cmdVerb, args)
+	if len(positionalArgs) < r.SkipArgs {
+		return resources.ResourceTypeUnknown
+	}
+	if r.TypeOnly {
+		if len(positionalArgs) > r.SkipArgs {
+			// The resource type is already provided
+			return resources.ResourceTypeUnknown
+		}
+		return resources.ResourceTypeApiResource
+	}
+	resourceType := resources.GetResourceType(cmdVerb, args)
+	if resourceType != resources.ResourceTypeApiResource && !r.IsAllowed(resourceType) {
+		logrus.Infof("Resource type %s is not accepted by %s", resourceType, cmdVerb)
+		return resources.ResourceTypeUnknown
+	}
+	return resourceType
+}
+
+// IsApiResourceAllowed returns true if the api resource can be used with the verb
+func (r *VerbRule) IsApiResourceAllowed(apiResource *resources.APIResource) bool {
+	if r.APIVerb != "" && !apiResource.SupportsVerb(r.APIVerb) {
+		return false
+	}
+	if r.Subresource != "" && !apiResource.HasSubresource(r.Subresource) {
+		return false
+	}
+	return r.IsAllowed(resources.ParseResourceType(apiResource.Name))
+}
+
+func (r *VerbRule) resourcesString() string {
+	if r.Contexts {
+		return "contexts"
+	}
+	if r.ImplicitType != resources.ResourceTypeApiResource {
+		return r.ImplicitType.String()
+	}
+	if len(r.ResourceTypes) == 0 {
+		return "All"
+	}
+	res := make([]string, len(r.ResourceTypes))
+	for k, v := range r.ResourceTypes {
+		res[k] = v.String()
+	}
+	return strings.Join(res, ",")
+}
+
+// getSubVerbCompletion lists the subcommands of the verb with the resources they accept
+func (r *VerbRule) getSubVerbCompletion() []string {
+	comps := []string{}
+	for name, rule := range r.SubVerbs {
+		comps = append(comps, util.DumpLines([]string{name, rule.resourcesString()})...)
+	}
+	sort.Strings(comps)
+	return comps
+}
+
+// ParseFlagAndResources returns the flag being completed and the resource
+// type to complete for the verb
+func ParseFlagAndResources(cmdVerb string, cmdArgs []string) (resourceType resources.ResourceType, flagCompletion parse.FlagCompletion, err error) {
+	resourceType = resources.ResourceTypeUnknown
+	flagCompletion = parse.CheckFlagManaged(cmdVerb, cmdArgs)
+	if flagCompletion == parse.FlagUnmanaged {
+		logrus.Infof("Flag is unmanaged in %s, bailing out", cmdArgs)
+		err = parse.UnmanagedFlagError(strings.Join(cmdArgs, " "))
+		return
+	}
+	logrus.Infof("Flag parsed: %s", flagCompletion.String())
+
+	if flagCompletion == parse.FlagNamespace {
+		resourceType = resources.ResourceTypeNamespace
+		return
+	}
+	if flagCompletion == parse.FlagDataKey {
+		var ok bool
+		resourceType, _, ok = parse.ParseDataKeyTarget(cmdArgs)
+		if !ok {
+			logrus.Infof("No secret or configmap in %s, bailing out", cmdArgs)
+			err = parse.UnmanagedFlagError(strings.Join(cmdArgs, " "))
+		}
+		return
+	}
+	rule, args, ok := GetVerbRule(cmdVerb, cmdArgs)
+	if ok && rule.SubVerbs == nil {
+		resourceType = rule.GetResourceType(cmdVerb, args)
+	}
+	if resourceType == resources.ResourceTypeUnknown {
+		err = resources.UnknownResourceError{ResourceStr: strings.Join(cmdArgs, " ")}
+		return
+	}
+	return
+}
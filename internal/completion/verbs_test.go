@@ -0,0 +1,144 @@
+package completion
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher/fetchertest"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerbResourceType(t *testing.T) {
+	testDatas := []struct {
+		verb         string
+		args         []string
+		resourceType resources.ResourceType
+	}{
+		{"get", []string{""}, resources.ResourceTypeApiResource},
+		{"get", []string{"pods"}, resources.ResourceTypeApiResource},
+		{"get", []string{"pods", ""}, resources.ResourceTypePod},
+		{"exec", []string{"-ti", " "}, resources.ResourceTypePod},
+		{"drain", []string{" "}, resources.ResourceTypeNode},
+		{"drain", []string{"--ignore-daemonsets", " "}, resources.ResourceTypeNode},
+		{"taint", []string{"nodes", " "}, resources.ResourceTypeNode},
+		{"taint", []string{"pods", " "}, resources.ResourceTypeUnknown},
+		{"scale", []string{"deploy", " "}, resources.ResourceTypeDeployment},
+		{"scale", []string{"pods", " "}, resources.ResourceTypeUnknown},
+		{"rollout", []string{" "}, resources.ResourceTypeUnknown},
+		{"rollout", []string{"restart", " "}, resources.ResourceTypeApiResource},
+		{"rollout", []string{"restart", "ds", " "}, resources.ResourceTypeDaemonSet},
+		{"rollout", []string{"status", "-n", "kube-system", "deploy", " "}, resources.ResourceTypeDeployment},
+		{"rollout", []string{"status", "pods", " "}, resources.ResourceTypeUnknown},
+		{"rollout", []string{"unknown", "deploy", " "}, resources.ResourceTypeUnknown},
+		{"set", []string{"env", "cronjobs", " "}, resources.ResourceTypeCronJob},
+		{"top", []string{"pods", " "}, resources.ResourceTypePod},
+		{"top", []string{"node", " "}, resources.ResourceTypeNode},
+		{"auth", []string{"can-i", " "}, resources.ResourceTypeUnknown},
+		{"auth", []string{"can-i", "list", " "}, resources.ResourceTypeApiResource},
+		{"auth", []string{"can-i", "list", "pods", " "}, resources.ResourceTypeUnknown},
+		{"explain", []string{" "}, resources.ResourceTypeApiResource},
+		{"explain", []string{"pods", " "}, resources.ResourceTypeUnknown},
+		{"debug", []string{"-it", " "}, resources.ResourceTypePod},
+	}
+	for _, testData := range testDatas {
+		resourceType, _, err := ParseFlagAndResources(testData.verb, testData.args)
+		assert.Equal(t, testData.resourceType, resourceType, "verb: %s, args: %s", testData.verb, testData.args)
+		if testData.resourceType == resources.ResourceTypeUnknown {
+			require.ErrorAs(t, err, &resources.UnknownResourceError{})
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestIsMultiSelect(t *testing.T) {
+	testDatas := []struct {
+		verb        string
+		args        []string
+		multiSelect bool
+	}{
+		{"get", []string{"pods", " "}, true},
+		{"delete", []string{"deploy/"}, true},
+		{"label", []string{"nodes", " "}, true},
+		{"annotate", []string{"pods,svc", " "}, true},
+		{"get", []string{" "}, false},
+		{"get", []string{"pods", "-n", " "}, false},
+		{"get", []string{"pods", "-l", " "}, false},
+		{"describe", []string{"pods", " "}, false},
+		{"exec", []string{" "}, false},
+		{"rollout", []string{"restart", "deploy", " "}, false},
+	}
+	for _, testData := range testDatas {
+		assert.Equal(t, testData.multiSelect, IsMultiSelect(testData.verb, testData.args), "verb: %s, args: %s", testData.verb, testData.args)
+	}
+
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "delete", []string{"pods", " "})
+	require.NoError(t, err)
+	assert.True(t, completionResults.MultiSelect)
+	completionResults, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "exec", []string{" "})
+	require.NoError(t, err)
+	assert.False(t, completionResults.MultiSelect)
+}
+
+func TestSubVerbCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "rollout", []string{" "})
+	require.NoError(t, err)
+	assert.Equal(t, resources.SubVerbHeader, completionResults.Header)
+	assert.Contains(t, completionResults.Completions, "restart\tdeployments,daemonsets,statefulsets")
+	assert.Len(t, completionResults.Completions, 6)
+
+	completionResults, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "top", []string{" "})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"node\tnodes", "pod\tpods"}, completionResults.Completions)
+}
+
+func TestFilteredApiResourceCompletion(t *testing.T) {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "rollout", []string{"restart", " "})
+	require.NoError(t, err)
+	assert.Equal(t, resources.ResourceToHeader(resources.ResourceTypeApiResource), completionResults.Header)
+	require.Len(t, completionResults.Completions, 3)
+	for _, comp := range completionResults.Completions {
+		assert.Regexp(t, "^(deployments|daemonsets|statefulsets)\t", comp)
+	}
+
+	completionResults, err = processCommandArgsWithFetchConfig(context.Background(), fetchConfig, "get", []string{" "})
+	require.NoError(t, err)
+	assert.Greater(t, len(completionResults.Completions), 3)
+}
+
+func getApiResourceNames(t *testing.T, cmdVerb string, args []string) []string {
+	fetchConfig := fetchertest.GetTestFetcherWithDefaults(t)
+	completionResults, err := processCommandArgsWithFetchConfig(context.Background(), fetchConfig, cmdVerb, args)
+	require.NoError(t, err)
+	names := []string{}
+	for _, comp := range completionResults.Completions {
+		names = append(names, strings.Split(comp, "\t")[0])
+	}
+	return names
+}
+
+func TestApiResourceVerbFiltering(t *testing.T) {
+	names := getApiResourceNames(t, "get", []string{" "})
+	assert.Contains(t, names, "componentstatuses")
+	assert.NotContains(t, names, "tokenreviews")
+	assert.Contains(t, names, "events")
+	assert.Contains(t, names, "events.events.k8s.io")
+	assert.Contains(t, names, "pods")
+
+	names = getApiResourceNames(t, "delete", []string{" "})
+	assert.NotContains(t, names, "componentstatuses")
+	assert.NotContains(t, names, "tokenreviews")
+	assert.Contains(t, names, "pods")
+
+	names = getApiResourceNames(t, "scale", []string{" "})
+	assert.Equal(t, []string{"deployments", "replicasets", "statefulsets"}, names)
+
+	names = getApiResourceNames(t, "explain", []string{" "})
+	assert.Contains(t, names, "tokenreviews")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/clusterconfig"
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	"github.com/pkg/errors"
 )
 
 // Fetcher defines configuration to fetch completion datas
@@ -41,6 +42,12 @@ func (f *Fetcher) LoadFetcherState() error {
 	return f.fetcherState.loadStateFromDisk()
 }
 
+// HasWarmCache returns true if resources of the context are available
+// without a remote fetch, either dumped locally or previously fetched
+func (f *Fetcher) HasWarmCache(context string) bool {
+	return f.HasLocalStore(context) || f.fetcherState.hasFetchedResources(context)
+}
+
 func (f *Fetcher) SaveFetcherState() error {
 	return f.fetcherState.writeToDisk()
 }
@@ -51,17 +58,33 @@ func loadResourceFromFile(filePath string) (map[string]resources.K8sResource, er
 	return resources, err
 }
 
-func (f *Fetcher) GetResources(ctx context.Context, r resources.ResourceType) (map[string]resources.K8sResource, error) {
+// GetCachedResources returns the resources available without a remote fetch,
+// either dumped locally or recently fetched. Nil is returned otherwise.
+func (f *Fetcher) GetCachedResources(r resources.ResourceType) (map[string]resources.K8sResource, error) {
+	if f.IsClusterOverridden() {
+		// Local files and cache are stored per context
+		return nil, nil
+	}
 	resources, err := f.checkLocalFiles(r)
 	if resources != nil || err != nil {
 		return resources, err
 	}
+	return f.checkRecentCache(r)
+}
 
-	// Check for recent cache
-	resources, err = f.checkRecentCache(r)
+func (f *Fetcher) GetResources(ctx context.Context, r resources.ResourceType) (map[string]resources.K8sResource, error) {
+	resources, err := f.GetCachedResources(r)
 	if resources != nil || err != nil {
 		return resources, err
 	}
+	if f.IsTargetOverridden() {
+		// The local server only serves the current context
+		resources, err = f.getResourcesFromPortForward(ctx, r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "context %s isn't served by the local kubectl-fzf-server and its kubectl-fzf pods can't be reached", f.GetContext())
+		}
+		return resources, nil
+	}
 
 	// Fetch remote
 	if util.IsAddressReachable(f.httpEndpoint) {
@@ -1,6 +1,7 @@
 package fetcher
 
 import (
+	"io"
 	"net/http"
 	"os"
 	"path"
@@ -25,7 +26,7 @@ func getLastModifiedFromHeader(headers http.Header) (time.Time, error) {
 }
 
 func (f *Fetcher) createCacheDir() (string, error) {
-	cacheDir := path.Join(f.fetcherCachePath, f.GetContext())
+	cacheDir := path.Join(f.fetcherCachePath, f.GetCacheKey())
 	logrus.Infof("Creating cache dir %s", cacheDir)
 	err := os.MkdirAll(cacheDir, 0755)
 	if err != nil {
@@ -41,7 +42,10 @@ func (f *Fetcher) writeResourceToCache(headers http.Header, b []byte, r resource
 	}
 	resourcePath := path.Join(cacheDir, r.String())
 	logrus.Debugf("Caching resource in %s", resourcePath)
-	err = os.WriteFile(resourcePath, b, 0644)
+	err = util.WriteFileAtomic(resourcePath, func(w io.Writer) error {
+		_, err := w.Write(b)
+		return err
+	})
 	if err != nil {
 		return errors.Wrap(err, "error writing cache file")
 	}
@@ -49,19 +53,19 @@ func (f *Fetcher) writeResourceToCache(headers http.Header, b []byte, r resource
 	if err != nil {
 		return err
 	}
-	f.fetcherState.updateLastModifiedTimes(f.GetContext(), r, lastModifiedTime)
+	f.fetcherState.updateLastModifiedTimes(f.GetCacheKey(), r, lastModifiedTime)
 	return nil
 }
 
 func (f *Fetcher) getResourceFromCache(r resources.ResourceType) (map[string]resources.K8sResource, error) {
-	cacheFile := path.Join(f.fetcherCachePath, f.GetContext(), r.String())
+	cacheFile := path.Join(f.fetcherCachePath, f.GetCacheKey(), r.String())
 	resources := map[string]resources.K8sResource{}
 	err := util.LoadGobFromFile(&resources, cacheFile)
 	return resources, err
 }
 
 func (f *Fetcher) checkRecentCache(r resources.ResourceType) (map[string]resources.K8sResource, error) {
-	cacheFile := path.Join(f.fetcherCachePath, f.GetContext(), r.String())
+	cacheFile := path.Join(f.fetcherCachePath, f.GetCacheKey(), r.String())
 	finfo, err := os.Stat(cacheFile)
 	if err != nil {
 		logrus.Infof("No cache file %s present", cacheFile)
@@ -80,7 +84,7 @@ func (f *Fetcher) checkRecentCache(r resources.ResourceType) (map[string]resourc
 }
 
 func (f *Fetcher) checkHttpCache(endpoint string, r resources.ResourceType) (map[string]resources.K8sResource, error) {
-	cacheFile := path.Join(f.fetcherCachePath, f.GetContext(), r.String())
+	cacheFile := path.Join(f.fetcherCachePath, f.GetCacheKey(), r.String())
 	finfo, err := os.Stat(cacheFile)
 	if err != nil {
 		logrus.Infof("No cache file %s present", cacheFile)
@@ -96,7 +100,7 @@ func (f *Fetcher) checkHttpCache(endpoint string, r resources.ResourceType) (map
 		return resources, err
 	}
 
-	localLastModified := f.fetcherState.getLastModifiedTime(f.GetContext(), r)
+	localLastModified := f.fetcherState.getLastModifiedTime(f.GetCacheKey(), r)
 	if localLastModified != nil {
 		resourcePath := f.getResourceHttpPath(endpoint, r)
 		headers, err := util.HeadFromHttpServer(resourcePath)
@@ -15,9 +15,15 @@ import (
 )
 
 func (f *Fetcher) loadResourceFromHttpServer(endpoint string, r resources.ResourceType) (map[string]resources.K8sResource, error) {
-	resources, err := f.checkHttpCache(endpoint, r)
-	if err != nil {
-		logrus.Infof("Error getting resources from cache: %s", err)
+	// The cache of the context can't hold the resources of an overridden cluster
+	useCache := !f.IsClusterOverridden()
+	var resources map[string]resources.K8sResource
+	var err error
+	if useCache {
+		resources, err = f.checkHttpCache(endpoint, r)
+		if err != nil {
+			logrus.Infof("Error getting resources from cache: %s", err)
+		}
 	}
 	if resources != nil {
 		logrus.Infof("Returning %s resources from cache", r.String())
@@ -29,9 +35,11 @@ func (f *Fetcher) loadResourceFromHttpServer(endpoint string, r resources.Resour
 	if err != nil {
 		return nil, errors.Wrap(err, "error reading body content")
 	}
-	err = f.writeResourceToCache(headers, body, r)
-	if err != nil {
-		return nil, errors.Wrap(err, "error writing fetcher cache")
+	if useCache {
+		err = f.writeResourceToCache(headers, body, r)
+		if err != nil {
+			return nil, errors.Wrap(err, "error writing fetcher cache")
+		}
 	}
 	util.DecodeGob(&resources, body)
 	return resources, err
@@ -44,49 +52,62 @@ func (f *Fetcher) getResourceHttpPath(host string, r resources.ResourceType) str
 
 func (f *Fetcher) getResourcesFromPortForward(ctx context.Context, r resources.ResourceType) (map[string]resources.K8sResource, error) {
 	logrus.Infof("Getting resources %s from port forward", r)
-	stopChan, err := f.openPortForward(ctx)
-	if err != nil {
-		return nil, err
+	var res map[string]resources.K8sResource
+	err := f.doWithPortForward(ctx, func(endpoint string) (err error) {
+		res, err = f.loadResourceFromHttpServer(endpoint, r)
+		return err
+	})
+	return res, err
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.DeletionTimestamp != nil {
+		return false
 	}
-	endpoint := fmt.Sprintf("localhost:%d", f.portForwardLocalPort)
-	resources, err := f.loadResourceFromHttpServer(endpoint, r)
-	stopChan <- struct{}{}
-	return resources, err
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
 }
 
-func (f *Fetcher) getKubectlFzfPod(ctx context.Context) (*corev1.Pod, error) {
+// getKubectlFzfPods returns the ready kubectl-fzf pods
+func (f *Fetcher) getKubectlFzfPods(ctx context.Context) ([]corev1.Pod, error) {
 	listOptions := metav1.ListOptions{
 		LabelSelector: "app=kubectl-fzf",
-		FieldSelector: "status.phase=Running",
 	}
 	clientset, err := f.GetClientset()
 	if err != nil {
 		return nil, err
 	}
-	ns := f.fetcherState.getFzfNamespace(f.GetContext())
+	ns := f.fetcherState.getFzfNamespace(f.GetCacheKey())
 	logrus.Infof("Looking for fzf pod in namespace '%s'", ns)
 	podList, err := clientset.CoreV1().Pods(ns).List(ctx, listOptions)
 	if err != nil {
 		return nil, err
 	}
-	if len(podList.Items) == 0 {
-		err = fmt.Errorf("no kubectl-fzf pods found, bailing out")
-		return nil, err
+	pods := []corev1.Pod{}
+	for _, pod := range podList.Items {
+		if !isPodReady(&pod) {
+			logrus.Debugf("kubectl-fzf pod %s is not ready, skipping it", pod.Name)
+			continue
+		}
+		if len(pod.Spec.Containers) != 1 {
+			logrus.Warnf("kubectl-fzf pod %s should have only one container, got %d", pod.Name, len(pod.Spec.Containers))
+			continue
+		}
+		pods = append(pods, pod)
 	}
-	pod := podList.Items[0]
-	if len(pod.Spec.Containers) != 1 {
-		err = fmt.Errorf("kubectl-fzf pod should have only one container, got %d", len(pod.Spec.Containers))
+	if len(pods) == 0 {
+		err = fmt.Errorf("no ready kubectl-fzf pods found among %d pods, bailing out", len(podList.Items))
 		return nil, err
 	}
-	f.fetcherState.updateNamespace(f.GetContext(), pod.GetNamespace())
-	return &pod, nil
+	f.fetcherState.updateNamespace(f.GetCacheKey(), pods[0].GetNamespace())
+	return pods, nil
 }
 
-func (f *Fetcher) getPortForwardRequest(ctx context.Context) (portForwardRequest portforward.PortForwardRequest, err error) {
-	pod, err := f.getKubectlFzfPod(ctx)
-	if err != nil {
-		return
-	}
+func (f *Fetcher) getPortForwardRequest(pod *corev1.Pod) (portForwardRequest portforward.PortForwardRequest, err error) {
 	containerPorts := pod.Spec.Containers[0].Ports
 	if len(containerPorts) != 1 {
 		err = fmt.Errorf("kubectl-fzf container should have only one port, got %d", len(containerPorts))
@@ -102,11 +123,11 @@ func (f *Fetcher) getPortForwardRequest(ctx context.Context) (portForwardRequest
 	return
 }
 
-func (f *Fetcher) openPortForward(ctx context.Context) (chan (struct{}), error) {
+func (f *Fetcher) openPortForward(pod *corev1.Pod) (chan (struct{}), error) {
 	stopChan := make(chan struct{})
 	readyChan := make(chan struct{})
 	errChan := make(chan error)
-	portForwardRequest, err := f.getPortForwardRequest(ctx)
+	portForwardRequest, err := f.getPortForwardRequest(pod)
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to create port forward")
 	}
@@ -129,3 +150,29 @@ func (f *Fetcher) openPortForward(ctx context.Context) (chan (struct{}), error)
 	logrus.Debug("Port forward ready")
 	return stopChan, nil
 }
+
+// doWithPortForward calls fn with a port-forwarded endpoint, trying the
+// next ready pod when the port-forward or fn fails
+func (f *Fetcher) doWithPortForward(ctx context.Context, fn func(endpoint string) error) error {
+	pods, err := f.getKubectlFzfPods(ctx)
+	if err != nil {
+		return err
+	}
+	endpoint := fmt.Sprintf("localhost:%d", f.portForwardLocalPort)
+	for i := range pods {
+		pod := &pods[i]
+		var stopChan chan struct{}
+		stopChan, err = f.openPortForward(pod)
+		if err != nil {
+			logrus.Infof("Port forward to %s failed, trying next pod: %s", pod.Name, err)
+			continue
+		}
+		err = fn(endpoint)
+		stopChan <- struct{}{}
+		if err == nil {
+			return nil
+		}
+		logrus.Infof("Request through %s failed, trying next pod: %s", pod.Name, err)
+	}
+	return errors.Wrapf(err, "all %d kubectl-fzf pods failed", len(pods))
+}
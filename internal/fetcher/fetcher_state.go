@@ -96,3 +96,8 @@ func (f *FetcherState) updateNamespace(context string, namespace string) {
 	f.hasChanged = true
 	contextState.FzfNamespace = namespace
 }
+
+func (f *FetcherState) hasFetchedResources(context string) bool {
+	contextState, ok := f.ContextStates[context]
+	return ok && len(contextState.LastModifiedTimes) > 0
+}
@@ -24,13 +24,12 @@ func (f *Fetcher) getStatsFromHttpServer(ctx context.Context, url string) ([]*st
 }
 
 func (f *Fetcher) getStatsFromPortForward(ctx context.Context) ([]*store.Stats, error) {
-	stopChan, err := f.openPortForward(ctx)
-	if err != nil {
-		return nil, err
-	}
-	url := fmt.Sprintf("http://%s/%s", fmt.Sprintf("localhost:%d", f.portForwardLocalPort), "stats")
-	stats, err := f.getStatsFromHttpServer(ctx, url)
-	stopChan <- struct{}{}
+	var stats []*store.Stats
+	err := f.doWithPortForward(ctx, func(endpoint string) (err error) {
+		url := fmt.Sprintf("http://%s/%s", endpoint, "stats")
+		stats, err = f.getStatsFromHttpServer(ctx, url)
+		return err
+	})
 	return stats, err
 }
 
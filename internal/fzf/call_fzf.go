@@ -34,7 +34,7 @@ func setCompsInStdin(cmd *exec.Cmd, comps string) error {
 	return nil
 }
 
-func CallFzf(comps string, query string) (string, error) {
+func CallFzf(comps string, query string, multiSelect bool) (string, error) {
 	var result strings.Builder
 	header := strings.Split(comps, "\n")[1]
 	// Leave an additional line for overflow
@@ -45,6 +45,9 @@ func CallFzf(comps string, query string) (string, error) {
 
 	// TODO Make fzf options configurable
 	fzfArgs := []string{"-1", "--header-lines=2", "--layout", "reverse", "-e", "--no-hscroll", "--no-sort", "--cycle", "-q", query, previewWindow, "--preview", previewCmd}
+	if multiSelect {
+		fzfArgs = append(fzfArgs, "--multi")
+	}
 	logrus.Infof("fzf args: %+v", fzfArgs)
 	cmd := exec.Command("fzf", fzfArgs...)
 	cmd.Stdout = &result
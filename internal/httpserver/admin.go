@@ -0,0 +1,226 @@
+package httpserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resourcewatcher"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// AdminController applies the runtime changes requested through the admin api
+type AdminController interface {
+	GetResourceWatcherCli() resourcewatcher.ResourceWatcherCli
+	// UpdateResourceWatcherCli applies the configuration returned by the update
+	// function. The update is called with the current configuration under lock.
+	UpdateResourceWatcherCli(update func(resourcewatcher.ResourceWatcherCli) (resourcewatcher.ResourceWatcherCli, error)) error
+	ResyncResource(resources.ResourceType) error
+	DumpResource(resources.ResourceType) error
+}
+
+// AdminConfig is the json representation of the watcher configuration.
+// Fields left empty in a PUT request keep their current value.
+type AdminConfig struct {
+	WatchResources         *[]string `json:"watch-resources,omitempty"`
+	ExcludeResources       *[]string `json:"exclude-resources,omitempty"`
+	WatchNamespaces        *[]string `json:"watch-namespaces,omitempty"`
+	ExcludeNamespaces      *[]string `json:"exclude-namespaces,omitempty"`
+	IgnoreNodeRoles        *[]string `json:"ignore-node-roles,omitempty"`
+	NodePollingPeriod      *string   `json:"node-polling-period,omitempty"`
+	NamespacePollingPeriod *string   `json:"namespace-polling-period,omitempty"`
+	ExitOnUnauthorized     *bool     `json:"exit-on-unauthorized,omitempty"`
+	AccessReview           *bool     `json:"access-review,omitempty"`
+	LegacyEndpoints        *bool     `json:"legacy-endpoints,omitempty"`
+	HideSecretKeys         *bool     `json:"hide-secret-keys,omitempty"`
+	ExcludeAnnotations     *[]string `json:"exclude-annotations,omitempty"`
+}
+
+func adminConfigFromCli(r resourcewatcher.ResourceWatcherCli) AdminConfig {
+	nodePollingPeriod := r.NodePollingPeriod.String()
+	namespacePollingPeriod := r.NamespacePollingPeriod.String()
+	return AdminConfig{
+		WatchResources:         &r.WatchResources,
+		ExcludeResources:       &r.ExcludeResources,
+		WatchNamespaces:        &r.WatchNamespaces,
+		ExcludeNamespaces:      &r.ExcludeNamespaces,
+		IgnoreNodeRoles:        &r.IgnoreNodeRoles,
+		NodePollingPeriod:      &nodePollingPeriod,
+		NamespacePollingPeriod: &namespacePollingPeriod,
+		ExitOnUnauthorized:     &r.ExitOnUnauthorized,
+		AccessReview:           &r.AccessReview,
+		LegacyEndpoints:        &r.LegacyEndpoints,
+		HideSecretKeys:         &r.HideSecretKeys,
+		ExcludeAnnotations:     &r.ExcludeAnnotations,
+	}
+}
+
+func parsePollingPeriod(name string, s string) (time.Duration, error) {
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", name, s, err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("%s needs to be positive, got %s", name, d)
+	}
+	return d, nil
+}
+
+// mergeInto applies the fields set in the admin config to the watcher configuration
+func (a AdminConfig) mergeInto(r resourcewatcher.ResourceWatcherCli) (resourcewatcher.ResourceWatcherCli, error) {
+	var err error
+	if a.WatchResources != nil {
+		r.WatchResources = *a.WatchResources
+	}
+	if a.ExcludeResources != nil {
+		r.ExcludeResources = *a.ExcludeResources
+	}
+	if a.WatchNamespaces != nil {
+		r.WatchNamespaces = *a.WatchNamespaces
+	}
+	if a.ExcludeNamespaces != nil {
+		r.ExcludeNamespaces = *a.ExcludeNamespaces
+	}
+	if a.IgnoreNodeRoles != nil {
+		r.IgnoreNodeRoles = *a.IgnoreNodeRoles
+	}
+	if a.NodePollingPeriod != nil {
+		r.NodePollingPeriod, err = parsePollingPeriod("node-polling-period", *a.NodePollingPeriod)
+		if err != nil {
+			return r, err
+		}
+	}
+	if a.NamespacePollingPeriod != nil {
+		r.NamespacePollingPeriod, err = parsePollingPeriod("namespace-polling-period", *a.NamespacePollingPeriod)
+		if err != nil {
+			return r, err
+		}
+	}
+	if a.ExitOnUnauthorized != nil {
+		r.ExitOnUnauthorized = *a.ExitOnUnauthorized
+	}
+	if a.AccessReview != nil {
+		r.AccessReview = *a.AccessReview
+	}
+	if a.LegacyEndpoints != nil {
+		r.LegacyEndpoints = *a.LegacyEndpoints
+	}
+	if a.HideSecretKeys != nil {
+		r.HideSecretKeys = *a.HideSecretKeys
+	}
+	if a.ExcludeAnnotations != nil {
+		r.ExcludeAnnotations = *a.ExcludeAnnotations
+	}
+	return r, nil
+}
+
+type adminServer struct {
+	controller AdminController
+	token      string
+}
+
+func (a *adminServer) authMiddleware(c *gin.Context) {
+	if a.token == "" {
+		return
+	}
+	auth := c.GetHeader("Authorization")
+	token := strings.TrimPrefix(auth, "Bearer ")
+	if token == auth || subtle.ConstantTimeCompare([]byte(token), []byte(a.token)) != 1 {
+		c.AbortWithStatus(http.StatusUnauthorized)
+	}
+}
+
+func (a *adminServer) getConfigRoute(c *gin.Context) {
+	c.JSON(http.StatusOK, adminConfigFromCli(a.controller.GetResourceWatcherCli()))
+}
+
+func (a *adminServer) putConfigRoute(c *gin.Context) {
+	adminConfig := AdminConfig{}
+	if err := c.ShouldBindJSON(&adminConfig); err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	err := a.controller.UpdateResourceWatcherCli(func(current resourcewatcher.ResourceWatcherCli) (resourcewatcher.ResourceWatcherCli, error) {
+		resourceWatcherCli, err := adminConfig.mergeInto(current)
+		if err != nil {
+			return current, err
+		}
+		logrus.Infof("Updating watcher configuration: %+v", resourceWatcherCli)
+		return resourceWatcherCli, nil
+	})
+	if err != nil {
+		c.String(http.StatusBadRequest, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, adminConfigFromCli(a.controller.GetResourceWatcherCli()))
+}
+
+type resourceActionFunc func(resources.ResourceType) error
+
+func resourceActionRoute(f resourceActionFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		resourceType := resources.ParseResourceType(c.Param("resource"))
+		if resourceType == resources.ResourceTypeUnknown {
+			c.String(http.StatusBadRequest, "Resource type unknown")
+			return
+		}
+		if err := f(resourceType); err != nil {
+			c.String(http.StatusNotFound, err.Error())
+			return
+		}
+		c.String(http.StatusOK, "Ok")
+	}
+}
+
+func (a *adminServer) setupRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(gin.LoggerWithWriter(gin.DefaultWriter))
+	router.Use(gin.Recovery())
+	adminRoute := router.Group("/admin", a.authMiddleware)
+	adminRoute.GET("/config", a.getConfigRoute)
+	adminRoute.PUT("/config", a.putConfigRoute)
+	adminRoute.POST("/resources/:resource/resync", resourceActionRoute(a.controller.ResyncResource))
+	adminRoute.POST("/resources/:resource/dump", resourceActionRoute(a.controller.DumpResource))
+	return router
+}
+
+func isLoopbackAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return false
+	}
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// StartAdminServer starts the admin api used to reconfigure the watchers at runtime.
+// A token is required if the admin api is not bound to a loopback address.
+func StartAdminServer(ctx context.Context, h *HttpServerConfigCli, controller AdminController) (int, error) {
+	if h.AdminListenAddress == "" {
+		return 0, nil
+	}
+	if h.AdminToken == "" && !isLoopbackAddress(h.AdminListenAddress) {
+		return 0, fmt.Errorf("admin api on non loopback address %s needs an admin token", h.AdminListenAddress)
+	}
+	listener, err := net.Listen("tcp", h.AdminListenAddress)
+	if err != nil {
+		return 0, err
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	a := adminServer{controller: controller, token: h.AdminToken}
+	srv := &http.Server{
+		Addr:    h.AdminListenAddress,
+		Handler: a.setupRouter(),
+	}
+	go startHttpServer(ctx, listener, srv)
+	return port, nil
+}
@@ -9,12 +9,17 @@ type HttpServerConfigCli struct {
 	ListenAddress   string
 	HttpProfAddress string
 	Debug           bool
+
+	AdminListenAddress string
+	AdminToken         string
 }
 
 func SetHttpServerConfigFlags(fs *pflag.FlagSet) {
 	fs.String("listen-address", "localhost:8080", "Listen address of the http server")
 	fs.String("http-prof-address", "localhost:6060", "Listen address of the pprof endpoint")
 	fs.Bool("http-debug", false, "Activate debug mode of the http server")
+	fs.String("admin-listen-address", "", "Listen address of the admin api, like localhost:8090. Disabled if empty.")
+	fs.String("admin-token", "", "Bearer token required by the admin api. Mandatory if the admin api doesn't listen on a loopback address.")
 }
 
 func GetHttpServerConfigCli() HttpServerConfigCli {
@@ -22,5 +27,7 @@ func GetHttpServerConfigCli() HttpServerConfigCli {
 	h.ListenAddress = viper.GetString("listen-address")
 	h.HttpProfAddress = viper.GetString("http-prof-address")
 	h.Debug = viper.GetBool("http-debug")
+	h.AdminListenAddress = viper.GetString("admin-listen-address")
+	h.AdminToken = viper.GetString("admin-token")
 	return h
 }
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
@@ -19,10 +21,18 @@ type FzfHttpServer struct {
 	ResourceHit int
 	//LastModifiedHit int
 
+	storesMutex sync.RWMutex
 	stores      []*store.Store
 	storeConfig *store.StoreConfig
 }
 
+// SetStores replaces the stores used to generate stats
+func (f *FzfHttpServer) SetStores(stores []*store.Store) {
+	f.storesMutex.Lock()
+	defer f.storesMutex.Unlock()
+	f.stores = stores
+}
+
 type routeResourceFunc func(*gin.Context, resources.ResourceType)
 
 func curryResourceRoute(f routeResourceFunc, resourceType resources.ResourceType) gin.HandlerFunc {
@@ -31,12 +41,32 @@ func curryResourceRoute(f routeResourceFunc, resourceType resources.ResourceType
 	}
 }
 
+// readinessRoute reports ready once all stores of the current cluster are ready,
+// after their initial listing or once the shared state of the leader is loaded
 func (f *FzfHttpServer) readinessRoute(c *gin.Context) {
+	f.storesMutex.RLock()
+	defer f.storesMutex.RUnlock()
+	notSynced := []string{}
+	for _, s := range f.stores {
+		if s.GetCluster() != f.storeConfig.GetContext() {
+			// Kept clusters don't serve completion
+			continue
+		}
+		if !s.IsReady() {
+			notSynced = append(notSynced, s.GetResourceType().String())
+		}
+	}
+	if len(notSynced) > 0 {
+		c.String(http.StatusServiceUnavailable, fmt.Sprintf("Waiting for initial sync of %s", strings.Join(notSynced, ",")))
+		return
+	}
 	c.String(http.StatusOK, "Ok")
 }
 
 func (f *FzfHttpServer) statsRoute(c *gin.Context) {
+	f.storesMutex.RLock()
 	stats := store.GetStatsFromStores(f.stores)
+	f.storesMutex.RUnlock()
 	logrus.Debugf("Sending stats: %v", stats)
 	c.JSON(http.StatusOK, stats)
 }
@@ -49,6 +79,10 @@ func (f *FzfHttpServer) resourcesRoute(c *gin.Context, resourceType resources.Re
 		c.String(http.StatusBadRequest, "Resource type unknown")
 		return
 	}
+	if s := f.getInMemoryStore(resourceType); s != nil {
+		f.inMemoryResourcesRoute(c, s)
+		return
+	}
 	if !f.storeConfig.FileStoreExists(resourceType) {
 		c.String(http.StatusNotFound, fmt.Sprintf("resource file for %s not found", resourceType))
 		return
@@ -58,6 +92,35 @@ func (f *FzfHttpServer) resourcesRoute(c *gin.Context, resourceType resources.Re
 	c.File(filePath)
 }
 
+// getInMemoryStore returns the store of the current cluster if the resource
+// type is only kept in memory
+func (f *FzfHttpServer) getInMemoryStore(resourceType resources.ResourceType) *store.Store {
+	f.storesMutex.RLock()
+	defer f.storesMutex.RUnlock()
+	for _, s := range f.stores {
+		if s.GetResourceType() == resourceType && s.IsInMemory() &&
+			s.GetCluster() == f.storeConfig.GetContext() {
+			return s
+		}
+	}
+	return nil
+}
+
+// inMemoryResourcesRoute encodes the current state of the store.
+// The last change of the store is sent as modified time so clients can
+// keep using their cache.
+func (f *FzfHttpServer) inMemoryResourcesRoute(c *gin.Context, s *store.Store) {
+	c.Header("Last-Modified", s.GetLastModified().UTC().Format(http.TimeFormat))
+	c.Status(http.StatusOK)
+	if c.Request.Method == "HEAD" {
+		return
+	}
+	err := s.EncodeState(c.Writer)
+	if err != nil {
+		logrus.Errorf("Error encoding %s: %s", s.GetResourceType(), err)
+	}
+}
+
 func (f *FzfHttpServer) setupRouter() *gin.Engine {
 	router := gin.New()
 	router.Use(gin.Recovery())
@@ -0,0 +1,121 @@
+package httpservertest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/httpserver"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resourcewatcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAdminController struct {
+	mutex   sync.Mutex
+	cli     resourcewatcher.ResourceWatcherCli
+	resyncs []resources.ResourceType
+}
+
+func (t *testAdminController) GetResourceWatcherCli() resourcewatcher.ResourceWatcherCli {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.cli
+}
+
+func (t *testAdminController) UpdateResourceWatcherCli(update func(resourcewatcher.ResourceWatcherCli) (resourcewatcher.ResourceWatcherCli, error)) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	r, err := update(t.cli)
+	if err != nil {
+		return err
+	}
+	t.cli = r
+	return nil
+}
+
+func (t *testAdminController) ResyncResource(r resources.ResourceType) error {
+	t.resyncs = append(t.resyncs, r)
+	return nil
+}
+
+func (t *testAdminController) DumpResource(r resources.ResourceType) error {
+	return fmt.Errorf("resource %s is not watched", r)
+}
+
+func adminRequest(t *testing.T, method string, url string, token string, body string) (int, string) {
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(b)
+}
+
+func TestAdminApi(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	controller := &testAdminController{cli: resourcewatcher.ResourceWatcherCli{
+		WatchResources:    []string{"pods"},
+		NodePollingPeriod: 300 * time.Second,
+	}}
+	h := &httpserver.HttpServerConfigCli{AdminListenAddress: "localhost:0", AdminToken: "secret"}
+	port, err := httpserver.StartAdminServer(ctx, h, controller)
+	require.NoError(t, err)
+	baseUrl := fmt.Sprintf("http://localhost:%d/admin", port)
+
+	code, _ := adminRequest(t, "GET", baseUrl+"/config", "", "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, body := adminRequest(t, "PUT", baseUrl+"/config", "secret",
+		`{"exclude-namespaces": ["kube-.*"], "node-polling-period": "1m"}`)
+	require.Equal(t, http.StatusOK, code, body)
+	assert.Equal(t, []string{"pods"}, controller.cli.WatchResources)
+	assert.Equal(t, []string{"kube-.*"}, controller.cli.ExcludeNamespaces)
+	assert.Equal(t, time.Minute, controller.cli.NodePollingPeriod)
+
+	code, _ = adminRequest(t, "PUT", baseUrl+"/config", "secret", `{"node-polling-period": "-1s"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// Concurrent updates of different fields are all kept
+	var wg sync.WaitGroup
+	for _, body := range []string{`{"watch-resources": ["pods", "nodes"]}`, `{"node-polling-period": "2m"}`,
+		`{"hide-secret-keys": true}`} {
+		wg.Add(1)
+		go func(body string) {
+			defer wg.Done()
+			code, resp := adminRequest(t, "PUT", baseUrl+"/config", "secret", body)
+			assert.Equal(t, http.StatusOK, code, resp)
+		}(body)
+	}
+	wg.Wait()
+	cli := controller.GetResourceWatcherCli()
+	assert.Equal(t, []string{"pods", "nodes"}, cli.WatchResources)
+	assert.Equal(t, 2*time.Minute, cli.NodePollingPeriod)
+	assert.True(t, cli.HideSecretKeys)
+	assert.Equal(t, []string{"kube-.*"}, cli.ExcludeNamespaces)
+
+	code, _ = adminRequest(t, "POST", baseUrl+"/resources/pods/resync", "secret", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []resources.ResourceType{resources.ResourceTypePod}, controller.resyncs)
+
+	code, _ = adminRequest(t, "POST", baseUrl+"/resources/pods/dump", "secret", "")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestAdminApiNeedsTokenOnPublicAddress(t *testing.T) {
+	h := &httpserver.HttpServerConfigCli{AdminListenAddress: "0.0.0.0:0"}
+	_, err := httpserver.StartAdminServer(context.Background(), h, &testAdminController{})
+	assert.Error(t, err)
+}
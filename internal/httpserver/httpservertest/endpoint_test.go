@@ -2,10 +2,18 @@ package httpservertest
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher/fetchertest"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/httpserver"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/clusterconfig"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/store"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/store/storetest"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -25,3 +33,29 @@ func TestHttpServerApiCompletion(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, s, 1)
 }
+
+func TestHttpServerReadiness(t *testing.T) {
+	ctx := context.Background()
+	_, podStore := storetest.GetTestPodStore(t)
+	storeConfig := store.NewStoreConfig(&store.StoreConfigCli{
+		ClusterConfigCli: &clusterconfig.ClusterConfigCli{ClusterName: podStore.GetCluster(), CacheDir: "./testdata"}})
+	// Stores of kept clusters are not waited for
+	keptStoreConfigCli := GetTestStoreConfigCli()
+	keptStoreConfigCli.TimeBetweenFullDump = time.Minute
+	keptStore := store.NewStore(ctx, store.NewStoreConfig(keptStoreConfigCli), resources.CtorConfig{}, resources.ResourceTypePod)
+	h := &httpserver.HttpServerConfigCli{ListenAddress: "localhost:0"}
+	fzfHttpServer, err := httpserver.StartHttpServer(ctx, h, storeConfig, []*store.Store{podStore, keptStore})
+	require.NoError(t, err)
+	url := fmt.Sprintf("http://localhost:%d/readiness", fzfHttpServer.Port)
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	podStore.MarkSynced()
+	resp, err = http.Get(url)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
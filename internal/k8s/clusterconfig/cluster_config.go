@@ -1,9 +1,14 @@
 package clusterconfig
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path"
+	"path/filepath"
+	"sort"
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
@@ -15,34 +20,115 @@ import (
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 )
 
+// ConfigOverrides are the kubeconfig flags provided on the kubectl command line
+type ConfigOverrides struct {
+	Context    string
+	Kubeconfig string
+	Cluster    string
+	User       string
+}
+
+func (o ConfigOverrides) isEmpty() bool {
+	return o == ConfigOverrides{}
+}
+
 type ClusterConfig struct {
 	clusterName string
+	cacheKey    string // Name of the cache dirs, the context name unless another kubeconfig is used
 	destDir     string
 	cacheDir    string
 
 	apiConfig *clientcmdapi.Config
+	overrides ConfigOverrides
+	// Set when the overrides target another context or cluster than the
+	// current context of the default kubeconfig
+	targetOverridden bool
+	// Set when the overrides replace the cluster of the context
+	clusterOverridden bool
 }
 
 func NewClusterConfig(clusterConfigCli *ClusterConfigCli) ClusterConfig {
 	c := ClusterConfig{}
 	c.clusterName = clusterConfigCli.ClusterName
+	c.cacheKey = c.clusterName
 	c.cacheDir = clusterConfigCli.CacheDir
-	c.destDir = path.Join(c.cacheDir, c.clusterName)
+	c.destDir = path.Join(c.cacheDir, c.cacheKey)
 	return c
 }
 
+// SetConfigOverrides sets the kubeconfig flags to apply in LoadClusterConfig
+func (c *ClusterConfig) SetConfigOverrides(overrides ConfigOverrides) {
+	c.overrides = overrides
+}
+
+// applyOverrides selects the context and replaces its cluster and user
+// with the ones provided on the command line
+func (c *ClusterConfig) applyOverrides() error {
+	c.targetOverridden = c.overrides.Kubeconfig != ""
+	c.clusterOverridden = false
+	if c.overrides.Context != "" {
+		if _, ok := c.apiConfig.Contexts[c.overrides.Context]; !ok {
+			return fmt.Errorf("context %s not found in config", c.overrides.Context)
+		}
+		if c.overrides.Context != c.apiConfig.CurrentContext {
+			c.targetOverridden = true
+		}
+		c.apiConfig.CurrentContext = c.overrides.Context
+	}
+	if c.overrides.Cluster == "" && c.overrides.User == "" {
+		return nil
+	}
+	contextStruct := clientcmdapi.NewContext()
+	if current, ok := c.apiConfig.Contexts[c.apiConfig.CurrentContext]; ok {
+		contextStruct = current.DeepCopy()
+	}
+	if c.overrides.Cluster != "" {
+		if _, ok := c.apiConfig.Clusters[c.overrides.Cluster]; !ok {
+			return fmt.Errorf("cluster %s not found in config", c.overrides.Cluster)
+		}
+		if contextStruct.Cluster != c.overrides.Cluster {
+			c.targetOverridden = true
+			c.clusterOverridden = true
+		}
+		contextStruct.Cluster = c.overrides.Cluster
+	}
+	if c.overrides.User != "" {
+		if _, ok := c.apiConfig.AuthInfos[c.overrides.User]; !ok {
+			return fmt.Errorf("user %s not found in config", c.overrides.User)
+		}
+		contextStruct.AuthInfo = c.overrides.User
+	}
+	c.apiConfig.Contexts[c.apiConfig.CurrentContext] = contextStruct
+	return nil
+}
+
 func (c *ClusterConfig) LoadClusterConfig() (err error) {
 	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = c.overrides.Kubeconfig
 	c.apiConfig, err = loadingRules.Load()
 	if err != nil {
 		return errors.Wrap(err, "error reading kubeconfig file")
 	}
+	err = c.applyOverrides()
+	if err != nil {
+		return err
+	}
 	c.clusterName = c.apiConfig.CurrentContext
 	if c.clusterName == "" {
 		logrus.Infof("Couldn't read kubeconfig file, assuming incluster")
 		c.clusterName = "incluster"
 	}
-	c.destDir = path.Join(c.cacheDir, c.clusterName)
+	c.cacheKey = c.clusterName
+	if c.overrides.Kubeconfig != "" {
+		// Contexts of another kubeconfig can share the name of a default context
+		kubeconfig, err := filepath.Abs(c.overrides.Kubeconfig)
+		if err != nil {
+			return errors.Wrap(err, "error getting kubeconfig path")
+		}
+		sum := sha256.Sum256([]byte(kubeconfig))
+		c.cacheKey = fmt.Sprintf("%s_%s", c.clusterName, hex.EncodeToString(sum[:])[:8])
+	}
+	c.destDir = path.Join(c.cacheDir, c.cacheKey)
 	logrus.Debugf("Cluster config set to target '%s'", c.destDir)
 	return nil
 }
@@ -86,12 +172,115 @@ func (c *ClusterConfig) GetContext() string {
 	return c.clusterName
 }
 
+// GetCacheKey returns the name of the cache dirs of the targeted cluster
+func (c *ClusterConfig) GetCacheKey() string {
+	return c.cacheKey
+}
+
+// IsTargetOverridden returns true if the kubeconfig flags target another
+// context or cluster than the current context of the default kubeconfig
+func (c *ClusterConfig) IsTargetOverridden() bool {
+	return c.targetOverridden
+}
+
+// IsClusterOverridden returns true if --cluster replaced the cluster of the
+// context. Resources don't belong to the context in this case.
+func (c *ClusterConfig) IsClusterOverridden() bool {
+	return c.clusterOverridden
+}
+
+// KubeContext is a context defined in the kubeconfig
+type KubeContext struct {
+	Name      string
+	Cluster   string
+	User      string
+	Namespace string
+	Current   bool
+}
+
+// GetKubeContexts returns the contexts of the kubeconfig sorted by name
+func (c *ClusterConfig) GetKubeContexts() []KubeContext {
+	if c.apiConfig == nil {
+		return nil
+	}
+	res := []KubeContext{}
+	for name, contextStruct := range c.apiConfig.Contexts {
+		res = append(res, KubeContext{
+			Name:      name,
+			Cluster:   contextStruct.Cluster,
+			User:      contextStruct.AuthInfo,
+			Namespace: contextStruct.Namespace,
+			Current:   name == c.apiConfig.CurrentContext,
+		})
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Name < res[j].Name })
+	return res
+}
+
+// HasLocalStore returns true if a local kubectl-fzf-server dumped resources of the context
+func (c *ClusterConfig) HasLocalStore(context string) bool {
+	entries, err := os.ReadDir(path.Join(c.cacheDir, context))
+	return err == nil && len(entries) > 0
+}
+
 func (c *ClusterConfig) GetClientConfig() (*rest.Config, error) {
-	restConfig, err := rest.InClusterConfig()
-	if err == nil {
-		return restConfig, nil
+	if c.overrides.isEmpty() {
+		restConfig, err := rest.InClusterConfig()
+		if err == nil {
+			return restConfig, nil
+		}
 	}
 	cmdConfig := clientcmd.NewDefaultClientConfig(*c.apiConfig, nil)
-	restConfig, err = cmdConfig.ClientConfig()
-	return restConfig, err
+	return cmdConfig.ClientConfig()
+}
+
+func (c *ClusterConfig) getCurrentContextConfig() (*clientcmdapi.Context, *clientcmdapi.Cluster, *clientcmdapi.AuthInfo) {
+	if c.apiConfig == nil {
+		return nil, nil, nil
+	}
+	contextStruct, ok := c.apiConfig.Contexts[c.apiConfig.CurrentContext]
+	if !ok {
+		return nil, nil, nil
+	}
+	return contextStruct, c.apiConfig.Clusters[contextStruct.Cluster], c.apiConfig.AuthInfos[contextStruct.AuthInfo]
+}
+
+// getCredentialFiles returns the files referenced by the cluster and user of the current context
+func (c *ClusterConfig) getCredentialFiles() []string {
+	_, cluster, authInfo := c.getCurrentContextConfig()
+	files := []string{}
+	if cluster != nil && cluster.CertificateAuthority != "" {
+		files = append(files, cluster.CertificateAuthority)
+	}
+	if authInfo != nil {
+		for _, f := range []string{authInfo.ClientCertificate, authInfo.ClientKey, authInfo.TokenFile} {
+			if f != "" {
+				files = append(files, f)
+			}
+		}
+	}
+	return files
+}
+
+// GetFingerprint returns a hash of the server and credentials of the current context.
+// Content of referenced credential files is included to detect rotations.
+func (c *ClusterConfig) GetFingerprint() string {
+	contextStruct, cluster, authInfo := c.getCurrentContextConfig()
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n", c.clusterName)
+	for _, v := range []interface{}{contextStruct, cluster, authInfo} {
+		b, err := json.Marshal(v)
+		if err != nil {
+			logrus.Warnf("Couldn't marshal kubeconfig entry: %s", err)
+		}
+		h.Write(b)
+	}
+	for _, f := range c.getCredentialFiles() {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			logrus.Debugf("Couldn't read credential file %s: %s", f, err)
+		}
+		h.Write(b)
+	}
+	return hex.EncodeToString(h.Sum(nil))
 }
@@ -0,0 +1,102 @@
+package clusterconfig
+
+import (
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testKubeconfig = `apiVersion: v1
+kind: Config
+current-context: staging
+clusters:
+- name: staging
+  cluster:
+    server: https://staging:6443
+- name: prod-eu
+  cluster:
+    server: https://prod-eu:6443
+users:
+- name: dev
+  user:
+    token: dev
+- name: admin
+  user:
+    token: admin
+contexts:
+- name: staging
+  context:
+    cluster: staging
+    user: dev
+    namespace: default
+- name: prod-eu
+  context:
+    cluster: prod-eu
+    user: admin
+    namespace: payments
+`
+
+func getTestClusterConfig(t *testing.T, overrides ConfigOverrides) ClusterConfig {
+	kubeconfig := path.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(kubeconfig, []byte(testKubeconfig), 0600))
+	t.Setenv("KUBECONFIG", kubeconfig)
+	c := NewClusterConfig(&ClusterConfigCli{CacheDir: t.TempDir()})
+	c.SetConfigOverrides(overrides)
+	return c
+}
+
+func TestLoadClusterConfigWithOverrides(t *testing.T) {
+	c := getTestClusterConfig(t, ConfigOverrides{})
+	require.NoError(t, c.LoadClusterConfig())
+	assert.Equal(t, "staging", c.GetContext())
+	assert.False(t, c.IsTargetOverridden())
+	namespace, err := c.GetNamespace()
+	require.NoError(t, err)
+	assert.Equal(t, "default", namespace)
+
+	c = getTestClusterConfig(t, ConfigOverrides{Context: "prod-eu"})
+	require.NoError(t, c.LoadClusterConfig())
+	assert.Equal(t, "prod-eu", c.GetContext())
+	assert.True(t, c.IsTargetOverridden())
+	assert.False(t, c.IsClusterOverridden())
+	namespace, err = c.GetNamespace()
+	require.NoError(t, err)
+	assert.Equal(t, "payments", namespace)
+	restConfig, err := c.GetClientConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "https://prod-eu:6443", restConfig.Host)
+	assert.Equal(t, "admin", restConfig.BearerToken)
+
+	c = getTestClusterConfig(t, ConfigOverrides{Context: "staging"})
+	require.NoError(t, c.LoadClusterConfig())
+	assert.False(t, c.IsTargetOverridden())
+
+	// Resources are still keyed by the context name
+	c = getTestClusterConfig(t, ConfigOverrides{Cluster: "prod-eu", User: "admin"})
+	require.NoError(t, c.LoadClusterConfig())
+	assert.Equal(t, "staging", c.GetContext())
+	assert.True(t, c.IsTargetOverridden())
+	assert.True(t, c.IsClusterOverridden())
+	namespace, err = c.GetNamespace()
+	require.NoError(t, err)
+	assert.Equal(t, "default", namespace)
+	restConfig, err = c.GetClientConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "https://prod-eu:6443", restConfig.Host)
+
+	// Contexts of another kubeconfig don't share the caches of the default one
+	c = getTestClusterConfig(t, ConfigOverrides{})
+	require.NoError(t, c.LoadClusterConfig())
+	assert.Equal(t, "staging", c.GetCacheKey())
+	c.SetConfigOverrides(ConfigOverrides{Kubeconfig: os.Getenv("KUBECONFIG")})
+	require.NoError(t, c.LoadClusterConfig())
+	assert.Equal(t, "staging", c.GetContext())
+	assert.True(t, c.IsTargetOverridden())
+	assert.Regexp(t, "^staging_[0-9a-f]{8}$", c.GetCacheKey())
+
+	c = getTestClusterConfig(t, ConfigOverrides{Context: "unknown"})
+	require.ErrorContains(t, c.LoadClusterConfig(), "context unknown not found")
+}
@@ -0,0 +1,107 @@
+package clusterconfig
+
+import (
+	"context"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// kubeconfigDebounce is the delay without file events before a reload is
+// triggered. Tools like kubectl write the kubeconfig in multiple steps.
+const kubeconfigDebounce = 500 * time.Millisecond
+
+// KubeconfigWatcher notifies when a file of the KUBECONFIG chain is modified
+type KubeconfigWatcher struct {
+	watcher *fsnotify.Watcher
+	files   map[string]bool
+	events  chan struct{}
+}
+
+// getKubeconfigFiles returns the kubeconfig files following the loading rules precedence
+func getKubeconfigFiles() []string {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if loadingRules.ExplicitPath != "" {
+		return []string{loadingRules.ExplicitPath}
+	}
+	return loadingRules.GetLoadingPrecedence()
+}
+
+// NewKubeconfigWatcher watches the kubeconfig files and the credential
+// files referenced by the given cluster config.
+// Directories are watched as kubeconfig files are usually replaced by a rename.
+func NewKubeconfigWatcher(ctx context.Context, c *ClusterConfig) (*KubeconfigWatcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+	k := &KubeconfigWatcher{
+		watcher: watcher,
+		files:   make(map[string]bool, 0),
+		events:  make(chan struct{}, 1),
+	}
+	watchedFiles := append(getKubeconfigFiles(), c.getCredentialFiles()...)
+	watchedDirs := make(map[string]bool, 0)
+	for _, file := range watchedFiles {
+		absFile, err := filepath.Abs(file)
+		if err != nil {
+			logrus.Warnf("Couldn't get absolute path of %s: %s", file, err)
+			continue
+		}
+		k.files[absFile] = true
+		dir := filepath.Dir(absFile)
+		if watchedDirs[dir] {
+			continue
+		}
+		err = watcher.Add(dir)
+		if err != nil {
+			logrus.Infof("Couldn't watch directory %s: %s", dir, err)
+			continue
+		}
+		watchedDirs[dir] = true
+	}
+	logrus.Infof("Watching kubeconfig files %v", watchedFiles)
+	go k.run(ctx)
+	return k, nil
+}
+
+// Events returns a channel receiving a value once modifications of watched files settle
+func (k *KubeconfigWatcher) Events() <-chan struct{} {
+	return k.events
+}
+
+func (k *KubeconfigWatcher) run(ctx context.Context) {
+	defer k.watcher.Close()
+	debounce := time.NewTimer(kubeconfigDebounce)
+	debounce.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			debounce.Stop()
+			return
+		case event, ok := <-k.watcher.Events:
+			if !ok {
+				return
+			}
+			if !k.files[filepath.Clean(event.Name)] {
+				continue
+			}
+			logrus.Debugf("Kubeconfig event %s", event)
+			debounce.Reset(kubeconfigDebounce)
+		case err, ok := <-k.watcher.Errors:
+			if !ok {
+				return
+			}
+			logrus.Warnf("Error watching kubeconfig: %s", err)
+		case <-debounce.C:
+			select {
+			case k.events <- struct{}{}:
+			default:
+				// A reload is already pending
+			}
+		}
+	}
+}
@@ -1,7 +1,9 @@
 package resources
 
 import (
+	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -17,17 +19,49 @@ type APIResourceList struct {
 func (r *APIResourceList) FromRuntime(obj interface{}, config CtorConfig) {
 	resourceList := obj.(*metav1.APIResourceList)
 	r.GroupVersion = resourceList.GroupVersion
+	group := ""
+	if i := strings.LastIndex(r.GroupVersion, "/"); i > 0 {
+		group = r.GroupVersion[:i]
+	}
 	for _, apiResource := range resourceList.APIResources {
+		if strings.Contains(apiResource.Name, "/") {
+			continue
+		}
 		a := APIResource{}
 		a.Shortnames = apiResource.ShortNames
 		a.Namespaced = apiResource.Namespaced
 		a.Kind = apiResource.Kind
 		a.Name = apiResource.Name
 		a.Version = r.GroupVersion
+		a.Group = group
+		a.Verbs = apiResource.Verbs
+		a.Categories = apiResource.Categories
 		r.ApiResources = append(r.ApiResources, a)
 	}
 }
 
+// SetSubresources fills the subresources of the api resources from the
+// full discovery lists, subresources are named like deployments/scale
+func (r *APIResourceList) SetSubresources(resourceLists []*metav1.APIResourceList) {
+	subresources := map[string][]string{}
+	for _, resourceList := range resourceLists {
+		if resourceList.GroupVersion != r.GroupVersion {
+			continue
+		}
+		for _, apiResource := range resourceList.APIResources {
+			name, subresource, found := strings.Cut(apiResource.Name, "/")
+			if found {
+				subresources[name] = append(subresources[name], subresource)
+			}
+		}
+	}
+	for i := range r.ApiResources {
+		s := subresources[r.ApiResources[i].Name]
+		sort.Strings(s)
+		r.ApiResources[i].Subresources = s
+	}
+}
+
 func (r *APIResourceList) HasChanged(k K8sResource) bool {
 	return true
 }
@@ -40,6 +74,10 @@ func (r *APIResourceList) GetLabels() map[string]string {
 	return nil
 }
 
+func (r *APIResourceList) GetAnnotations() map[string]string {
+	return nil
+}
+
 func (r *APIResourceList) GetFieldSelectors() map[string]string {
 	return nil
 }
@@ -55,11 +93,41 @@ func (r *APIResourceList) ToStrings() []string {
 
 // APIResource is the summary of a kubernetes pod
 type APIResource struct {
-	Name       string
-	Shortnames []string
-	Version    string
-	Namespaced bool
-	Kind       string
+	Name         string
+	Shortnames   []string
+	Version      string
+	Group        string
+	Namespaced   bool
+	Kind         string
+	Verbs        []string
+	Categories   []string
+	Subresources []string
+}
+
+// QualifiedName returns the name suffixed by the group, like events.events.k8s.io
+func (a *APIResource) QualifiedName() string {
+	if a.Group == "" {
+		return a.Name
+	}
+	return a.Name + "." + a.Group
+}
+
+// SupportsVerb returns true if the api resource accepts the verb.
+// Resources dumped without their verbs accept everything.
+func (a *APIResource) SupportsVerb(verb string) bool {
+	if len(a.Verbs) == 0 {
+		return true
+	}
+	return util.IsStringIn(verb, a.Verbs)
+}
+
+// HasSubresource returns true if the api resource has the subresource, like scale.
+// Resources dumped without their verbs accept everything.
+func (a *APIResource) HasSubresource(subresource string) bool {
+	if len(a.Verbs) == 0 {
+		return true
+	}
+	return util.IsStringIn(subresource, a.Subresources)
 }
 
 func (a *APIResource) ToStrings() []string {
@@ -69,6 +137,7 @@ func (a *APIResource) ToStrings() []string {
 		a.Version,
 		strconv.FormatBool(a.Namespaced),
 		a.Kind,
+		util.JoinSlicesOrNone(a.Categories, ","),
 	}
 	return util.DumpLines(lst)
 }
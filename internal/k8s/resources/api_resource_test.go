@@ -0,0 +1,42 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAPIResourceListFromRuntime(t *testing.T) {
+	resourceList := &metav1.APIResourceList{
+		GroupVersion: "apps/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "deployments", ShortNames: []string{"deploy"}, Kind: "Deployment", Namespaced: true,
+				Verbs: []string{"get", "list", "delete"}, Categories: []string{"all"}},
+			{Name: "deployments/scale", Kind: "Scale", Namespaced: true, Verbs: []string{"get", "patch"}},
+			{Name: "controllerrevisions", Kind: "ControllerRevision", Namespaced: true, Verbs: []string{"get", "list"}},
+		},
+	}
+	a := APIResourceList{}
+	a.FromRuntime(resourceList, CtorConfig{})
+	a.SetSubresources([]*metav1.APIResourceList{resourceList})
+
+	require.Len(t, a.ApiResources, 2)
+	deployments := a.ApiResources[0]
+	assert.Equal(t, "apps", deployments.Group)
+	assert.Equal(t, "deployments.apps", deployments.QualifiedName())
+	assert.Equal(t, []string{"scale"}, deployments.Subresources)
+	assert.True(t, deployments.SupportsVerb("delete"))
+	assert.True(t, deployments.HasSubresource("scale"))
+	assert.Equal(t, []string{"deployments\tdeploy\tapps/v1\ttrue\tDeployment\tall"}, deployments.ToStrings())
+
+	controllerRevisions := a.ApiResources[1]
+	assert.False(t, controllerRevisions.SupportsVerb("delete"))
+	assert.False(t, controllerRevisions.HasSubresource("scale"))
+	assert.Equal(t, []string{"controllerrevisions\tNone\tapps/v1\ttrue\tControllerRevision\tNone"}, controllerRevisions.ToStrings())
+
+	core := APIResource{Name: "pods", Version: "v1"}
+	assert.Equal(t, "pods", core.QualifiedName())
+	assert.True(t, core.SupportsVerb("delete"))
+}
@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterRole is the summary of a kubernetes cluster role
+type ClusterRole struct {
+	ResourceMeta
+	NumberRules       string
+	AggregationLabels []string
+}
+
+// NewClusterRoleFromRuntime builds a cluster role from informer result
+func NewClusterRoleFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	c := &ClusterRole{}
+	c.FromRuntime(obj, config)
+	return c
+}
+
+func labelSelectorToStrings(selector metav1.LabelSelector) []string {
+	res := util.JoinStringMap(selector.MatchLabels, nil, "=")
+	sort.Strings(res)
+	for _, expression := range selector.MatchExpressions {
+		res = append(res, fmt.Sprintf("%s:%s:%s", expression.Key,
+			expression.Operator, strings.Join(expression.Values, ";")))
+	}
+	return res
+}
+
+// FromRuntime builds object from the informer's result
+func (c *ClusterRole) FromRuntime(obj interface{}, config CtorConfig) {
+	clusterRole := obj.(*rbacv1.ClusterRole)
+	c.FromObjectMeta(clusterRole.ObjectMeta, config)
+	c.NumberRules = strconv.Itoa(len(clusterRole.Rules))
+	c.AggregationLabels = nil
+	if clusterRole.AggregationRule != nil {
+		for _, selector := range clusterRole.AggregationRule.ClusterRoleSelectors {
+			c.AggregationLabels = append(c.AggregationLabels, labelSelectorToStrings(selector)...)
+		}
+	}
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (c *ClusterRole) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (c *ClusterRole) ToStrings() []string {
+	lst := []string{
+		c.Name,
+		c.NumberRules,
+		util.JoinSlicesOrNone(c.AggregationLabels, ","),
+		c.resourceAge(),
+		c.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
@@ -0,0 +1,45 @@
+package resources
+
+import (
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// ClusterRoleBinding is the summary of a kubernetes cluster role binding
+type ClusterRoleBinding struct {
+	ResourceMeta
+	Role     string
+	Subjects []string
+}
+
+// NewClusterRoleBindingFromRuntime builds a cluster role binding from informer result
+func NewClusterRoleBindingFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	c := &ClusterRoleBinding{}
+	c.FromRuntime(obj, config)
+	return c
+}
+
+// FromRuntime builds object from the informer's result
+func (c *ClusterRoleBinding) FromRuntime(obj interface{}, config CtorConfig) {
+	clusterRoleBinding := obj.(*rbacv1.ClusterRoleBinding)
+	c.FromObjectMeta(clusterRoleBinding.ObjectMeta, config)
+	c.Role = roleRefToString(clusterRoleBinding.RoleRef)
+	c.Subjects = subjectsToStrings(clusterRoleBinding.Subjects)
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (c *ClusterRoleBinding) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (c *ClusterRoleBinding) ToStrings() []string {
+	lst := []string{
+		c.Name,
+		c.Role,
+		util.JoinSlicesWithMaxOrNone(c.Subjects, 20, ","),
+		c.resourceAge(),
+		c.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
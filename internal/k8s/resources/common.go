@@ -3,6 +3,9 @@ package resources
 // CtorConfig is the configuration passed to all resource constructors
 type CtorConfig struct {
 	IgnoredNodeRoles map[string]bool
+	HideSecretKeys   bool
+	// ExcludedAnnotations are the annotation keys dropped from the dump
+	ExcludedAnnotations map[string]bool
 }
 
 type ResourceCtor func(obj interface{}, config CtorConfig) K8sResource
@@ -45,6 +48,34 @@ func ResourceTypeToCtor(resourceType ResourceType) ResourceCtor {
 		return NewNodeFromRuntime
 	case ResourceTypeNamespace:
 		return NewNamespaceFromRuntime
+	case ResourceTypeRole:
+		return NewRoleFromRuntime
+	case ResourceTypeRoleBinding:
+		return NewRoleBindingFromRuntime
+	case ResourceTypeClusterRole:
+		return NewClusterRoleFromRuntime
+	case ResourceTypeClusterRoleBinding:
+		return NewClusterRoleBindingFromRuntime
+	case ResourceTypeNetworkPolicy:
+		return NewNetworkPolicyFromRuntime
+	case ResourceTypePodDisruptionBudget:
+		return NewPodDisruptionBudgetFromRuntime
+	case ResourceTypeResourceQuota:
+		return NewResourceQuotaFromRuntime
+	case ResourceTypeLimitRange:
+		return NewLimitRangeFromRuntime
+	case ResourceTypeStorageClass:
+		return NewStorageClassFromRuntime
+	case ResourceTypeIngressClass:
+		return NewIngressClassFromRuntime
+	case ResourceTypePriorityClass:
+		return NewPriorityClassFromRuntime
+	case ResourceTypeCSIDriver:
+		return NewCSIDriverFromRuntime
+	case ResourceTypeVolumeAttachment:
+		return NewVolumeAttachmentFromRuntime
+	case ResourceTypeEvent:
+		return NewEventFromRuntime
 	}
 	return nil
 }
@@ -1,6 +1,8 @@
 package resources
 
 import (
+	"sort"
+
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -8,6 +10,7 @@ import (
 // ConfigMap is the summary of a kubernetes configMap
 type ConfigMap struct {
 	ResourceMeta
+	Keys []string
 }
 
 // NewConfigMapFromRuntime builds a pod from informer result
@@ -21,6 +24,16 @@ func NewConfigMapFromRuntime(obj interface{}, config CtorConfig) K8sResource {
 func (c *ConfigMap) FromRuntime(obj interface{}, config CtorConfig) {
 	configMap := obj.(*corev1.ConfigMap)
 	c.FromObjectMeta(configMap.ObjectMeta, config)
+	c.Keys = make([]string, 0, len(configMap.Data))
+	for k := range configMap.Data {
+		c.Keys = append(c.Keys, k)
+	}
+	sort.Strings(c.Keys)
+}
+
+// GetDataKeys returns the sorted key names of the configMap's data
+func (c *ConfigMap) GetDataKeys() []string {
+	return c.Keys
 }
 
 // HasChanged returns true if the resource's dump needs to be updated
@@ -33,6 +46,7 @@ func (c *ConfigMap) ToStrings() []string {
 	line := []string{
 		c.Namespace,
 		c.Name,
+		util.TruncateString(util.JoinSlicesOrNone(c.Keys, ","), 300),
 		c.resourceAge(),
 		c.labelsString(),
 	}
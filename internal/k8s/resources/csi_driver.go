@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"strconv"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+// CSIDriver is the summary of a kubernetes csi driver
+type CSIDriver struct {
+	ResourceMeta
+	AttachRequired  string
+	PodInfoOnMount  string
+	StorageCapacity string
+	Modes           []string
+}
+
+// NewCSIDriverFromRuntime builds a csi driver from informer result
+func NewCSIDriverFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	c := &CSIDriver{}
+	c.FromRuntime(obj, config)
+	return c
+}
+
+func boolPtrToString(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+// FromRuntime builds object from the informer's result
+func (c *CSIDriver) FromRuntime(obj interface{}, config CtorConfig) {
+	csiDriver := obj.(*storagev1.CSIDriver)
+	c.FromObjectMeta(csiDriver.ObjectMeta, config)
+	c.AttachRequired = boolPtrToString(csiDriver.Spec.AttachRequired)
+	c.PodInfoOnMount = boolPtrToString(csiDriver.Spec.PodInfoOnMount)
+	c.StorageCapacity = boolPtrToString(csiDriver.Spec.StorageCapacity)
+	c.Modes = nil
+	for _, mode := range csiDriver.Spec.VolumeLifecycleModes {
+		c.Modes = append(c.Modes, string(mode))
+	}
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (c *CSIDriver) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (c *CSIDriver) ToStrings() []string {
+	lst := []string{
+		c.Name,
+		c.AttachRequired,
+		c.PodInfoOnMount,
+		c.StorageCapacity,
+		util.JoinSlicesOrNone(c.Modes, ","),
+		c.resourceAge(),
+		c.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
@@ -18,6 +18,19 @@ type DaemonSet struct {
 	Ready         string
 	Containers    []string
 	LabelSelector []string
+	WorkloadTemplate
+}
+
+func getDaemonSetRolloutStatus(daemonset *appsv1.DaemonSet) string {
+	status := daemonset.Status
+	if status.ObservedGeneration < daemonset.Generation {
+		return RolloutProgressing
+	}
+	if status.UpdatedNumberScheduled < status.DesiredNumberScheduled ||
+		status.NumberAvailable < status.DesiredNumberScheduled {
+		return RolloutProgressing
+	}
+	return RolloutComplete
 }
 
 // NewDaemonSetFromRuntime builds a daemonset from informer result
@@ -50,6 +63,11 @@ func (d *DaemonSet) FromRuntime(obj interface{}, config CtorConfig) {
 	for k, v := range containers {
 		d.Containers[k] = v.Name
 	}
+
+	d.fromPodTemplate(daemonset.Spec.Template)
+	d.setGeneration(status.ObservedGeneration, daemonset.Generation)
+	d.UpdateStrategy = string(daemonset.Spec.UpdateStrategy.Type)
+	d.RolloutStatus = getDaemonSetRolloutStatus(daemonset)
 }
 
 // HasChanged returns true if the resource's dump needs to be updated
@@ -67,8 +85,9 @@ func (d *DaemonSet) ToStrings() []string {
 		d.Ready,
 		util.JoinSlicesOrNone(d.LabelSelector, ","),
 		util.JoinSlicesOrNone(d.Containers, ","),
-		d.resourceAge(),
-		d.labelsString(),
 	}
+	// Containers are already displayed
+	lst = append(lst, d.WorkloadTemplate.toStrings()[1:]...)
+	lst = append(lst, d.resourceAge(), d.labelsString())
 	return util.DumpLines(lst)
 }
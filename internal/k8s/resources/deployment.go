@@ -6,6 +6,7 @@ import (
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
 
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // Deployment is the summary of a kubernetes deployment
@@ -15,6 +16,33 @@ type Deployment struct {
 	AvailableReplicas string
 	UpdatedReplicas   string
 	CurrentReplicas   string
+	WorkloadTemplate
+}
+
+// getDeploymentRolloutStatus follows the logic of kubectl rollout status
+func getDeploymentRolloutStatus(deployment *appsv1.Deployment) string {
+	for _, c := range deployment.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Reason == "ProgressDeadlineExceeded" {
+			return RolloutStalled
+		}
+		if c.Type == appsv1.DeploymentReplicaFailure && c.Status == corev1.ConditionTrue {
+			return RolloutStalled
+		}
+	}
+	status := deployment.Status
+	if status.ObservedGeneration < deployment.Generation {
+		return RolloutProgressing
+	}
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	if status.UpdatedReplicas < desired ||
+		status.Replicas > status.UpdatedReplicas ||
+		status.AvailableReplicas < status.UpdatedReplicas {
+		return RolloutProgressing
+	}
+	return RolloutComplete
 }
 
 // NewDeploymentFromRuntime builds a k8sresource from informer result
@@ -37,6 +65,11 @@ func (d *Deployment) FromRuntime(obj interface{}, config CtorConfig) {
 	d.CurrentReplicas = strconv.Itoa(int(status.Replicas))
 	d.UpdatedReplicas = strconv.Itoa(int(status.UpdatedReplicas))
 	d.AvailableReplicas = strconv.Itoa(int(status.AvailableReplicas))
+
+	d.fromPodTemplate(deployment.Spec.Template)
+	d.setGeneration(status.ObservedGeneration, deployment.Generation)
+	d.UpdateStrategy = string(deployment.Spec.Strategy.Type)
+	d.RolloutStatus = getDeploymentRolloutStatus(deployment)
 }
 
 // HasChanged returns true if the resource's dump needs to be updated
@@ -53,8 +86,8 @@ func (d *Deployment) ToStrings() []string {
 		d.CurrentReplicas,
 		d.UpdatedReplicas,
 		d.AvailableReplicas,
-		d.resourceAge(),
-		d.labelsString(),
 	}
+	line = append(line, d.WorkloadTemplate.toStrings()...)
+	line = append(line, d.resourceAge(), d.labelsString())
 	return util.DumpLines(line)
 }
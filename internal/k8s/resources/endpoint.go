@@ -1,18 +1,25 @@
 package resources
 
 import (
+	"fmt"
+
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 )
 
-// Endpoint is the summary of a kubernetes endpoints
+// Endpoint is the summary of a kubernetes endpoints.
+// It is built either from the legacy endpoints or from an endpoint slice,
+// slices of the same service are merged when dumped.
 type Endpoints struct {
 	ResourceMeta
 	ReadyIps     []string
 	ReadyPods    []string
 	NotReadyIps  []string
 	NotReadyPods []string
+	Ports        []string
+	Zones        []string
 }
 
 // NewEndpointsFromRuntime builds a k8s resource from informer result
@@ -24,7 +31,29 @@ func NewEndpointsFromRuntime(obj interface{}, config CtorConfig) K8sResource {
 
 // FromRuntime builds object from the informer's result
 func (e *Endpoints) FromRuntime(obj interface{}, config CtorConfig) {
-	endpoints := obj.(*corev1.Endpoints)
+	switch v := obj.(type) {
+	case *corev1.Endpoints:
+		e.fromEndpoints(v, config)
+	case *discoveryv1.EndpointSlice:
+		e.fromEndpointSlice(v, config)
+	}
+}
+
+func appendUnique(sl []string, s string) []string {
+	if util.IsStringIn(s, sl) {
+		return sl
+	}
+	return append(sl, s)
+}
+
+func portToString(name string, port int32, protocol string) string {
+	if name == "" {
+		return fmt.Sprintf("%d/%s", port, protocol)
+	}
+	return fmt.Sprintf("%s:%d/%s", name, port, protocol)
+}
+
+func (e *Endpoints) fromEndpoints(endpoints *corev1.Endpoints, config CtorConfig) {
 	e.FromObjectMeta(endpoints.ObjectMeta, config)
 	for _, subsets := range endpoints.Subsets {
 		for _, v := range subsets.Addresses {
@@ -39,16 +68,117 @@ func (e *Endpoints) FromRuntime(obj interface{}, config CtorConfig) {
 				e.NotReadyPods = append(e.NotReadyPods, v.TargetRef.Name)
 			}
 		}
+		for _, p := range subsets.Ports {
+			e.Ports = appendUnique(e.Ports, portToString(p.Name, p.Port, string(p.Protocol)))
+		}
+	}
+}
+
+func (e *Endpoints) fromEndpointSlice(slice *discoveryv1.EndpointSlice, config CtorConfig) {
+	e.FromObjectMeta(slice.ObjectMeta, config)
+	if serviceName, ok := slice.Labels[discoveryv1.LabelServiceName]; ok {
+		e.Name = serviceName
+	}
+	// Only keep the labels copied from the service
+	e.Labels = make(map[string]string, len(slice.Labels))
+	for k, v := range slice.Labels {
+		if k != discoveryv1.LabelServiceName && k != discoveryv1.LabelManagedBy {
+			e.Labels[k] = v
+		}
+	}
+	for _, endpoint := range slice.Endpoints {
+		// A nil ready condition means unknown and should be interpreted as ready
+		ready := endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+		addresses := endpoint.Addresses
+		if slice.AddressType == discoveryv1.AddressTypeFQDN && endpoint.Hostname != nil {
+			addresses = []string{*endpoint.Hostname}
+		}
+		podName := ""
+		if endpoint.TargetRef != nil && endpoint.TargetRef.Kind == "Pod" {
+			podName = endpoint.TargetRef.Name
+		}
+		if ready {
+			e.ReadyIps = append(e.ReadyIps, addresses...)
+			if podName != "" {
+				e.ReadyPods = appendUnique(e.ReadyPods, podName)
+			}
+		} else {
+			e.NotReadyIps = append(e.NotReadyIps, addresses...)
+			if podName != "" {
+				e.NotReadyPods = appendUnique(e.NotReadyPods, podName)
+			}
+		}
+		if endpoint.Zone != nil {
+			e.Zones = appendUnique(e.Zones, *endpoint.Zone)
+		}
+		if endpoint.Hints != nil {
+			for _, zone := range endpoint.Hints.ForZones {
+				e.Zones = appendUnique(e.Zones, fmt.Sprintf("hint:%s", zone.Name))
+			}
+		}
+	}
+	for _, p := range slice.Ports {
+		name := ""
+		if p.Name != nil {
+			name = *p.Name
+		}
+		var port int32
+		if p.Port != nil {
+			port = *p.Port
+		}
+		protocol := corev1.ProtocolTCP
+		if p.Protocol != nil {
+			protocol = *p.Protocol
+		}
+		e.Ports = appendUnique(e.Ports, portToString(name, port, string(protocol)))
 	}
 }
 
 // HasChanged returns true if the resource's dump needs to be updated
 func (e *Endpoints) HasChanged(k K8sResource) bool {
-	oldE := k.(*Endpoints)
+	oldE, ok := k.(*Endpoints)
+	if !ok {
+		return true
+	}
 	return !(util.StringSlicesEqual(e.ReadyIps, oldE.ReadyIps) &&
 		util.StringSlicesEqual(e.ReadyPods, oldE.ReadyPods) &&
 		util.StringSlicesEqual(e.NotReadyIps, oldE.NotReadyIps) &&
-		util.StringSlicesEqual(e.NotReadyIps, oldE.NotReadyIps))
+		util.StringSlicesEqual(e.NotReadyPods, oldE.NotReadyPods) &&
+		util.StringSlicesEqual(e.Ports, oldE.Ports) &&
+		util.StringSlicesEqual(e.Zones, oldE.Zones))
+}
+
+// MergeKey returns the service of the endpoints
+func (e *Endpoints) MergeKey() string {
+	return fmt.Sprintf("%s_%s", e.Namespace, e.Name)
+}
+
+// Merge combines the endpoints of two slices of the same service
+func (e *Endpoints) Merge(k K8sResource) K8sResource {
+	other := k.(*Endpoints)
+	res := *e
+	if other.CreationTime.Before(e.CreationTime) {
+		res.CreationTime = other.CreationTime
+	}
+	res.ReadyIps = append(append([]string{}, e.ReadyIps...), other.ReadyIps...)
+	res.NotReadyIps = append(append([]string{}, e.NotReadyIps...), other.NotReadyIps...)
+	res.ReadyPods = append([]string{}, e.ReadyPods...)
+	for _, pod := range other.ReadyPods {
+		res.ReadyPods = appendUnique(res.ReadyPods, pod)
+	}
+	res.NotReadyPods = append([]string{}, e.NotReadyPods...)
+	for _, pod := range other.NotReadyPods {
+		res.NotReadyPods = appendUnique(res.NotReadyPods, pod)
+	}
+	res.Ports = append([]string{}, e.Ports...)
+	for _, port := range other.Ports {
+		res.Ports = appendUnique(res.Ports, port)
+	}
+	res.Zones = append([]string{}, e.Zones...)
+	for _, zone := range other.Zones {
+		res.Zones = appendUnique(res.Zones, zone)
+	}
+	return &res
 }
 
 // ToString serializes the object to strings
@@ -61,6 +191,8 @@ func (e *Endpoints) ToStrings() []string {
 		util.JoinSlicesWithMaxOrNone(e.ReadyPods, 20, ","),
 		util.JoinSlicesWithMaxOrNone(e.NotReadyIps, 20, ","),
 		util.JoinSlicesWithMaxOrNone(e.NotReadyPods, 20, ","),
+		util.JoinSlicesOrNone(e.Ports, ","),
+		util.JoinSlicesOrNone(e.Zones, ","),
 		e.labelsString(),
 	}
 	return util.DumpLines(line)
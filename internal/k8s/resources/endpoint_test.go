@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestEndpointSlice(name string, addressType discoveryv1.AddressType, address string, ready bool, pod string) *discoveryv1.EndpointSlice {
+	zone := "us-east-1a"
+	portName := "http"
+	port := int32(8080)
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels: map[string]string{
+				discoveryv1.LabelServiceName: "web",
+				discoveryv1.LabelManagedBy:   "endpointslice-controller.k8s.io",
+				"app":                        "web",
+			},
+		},
+		AddressType: addressType,
+		Endpoints: []discoveryv1.Endpoint{{
+			Addresses:  []string{address},
+			Conditions: discoveryv1.EndpointConditions{Ready: &ready},
+			TargetRef:  &corev1.ObjectReference{Kind: "Pod", Name: pod},
+			Zone:       &zone,
+			Hints:      &discoveryv1.EndpointHints{ForZones: []discoveryv1.ForZone{{Name: zone}}},
+		}},
+		Ports: []discoveryv1.EndpointPort{{Name: &portName, Port: &port}},
+	}
+}
+
+func TestEndpointSlicesMerge(t *testing.T) {
+	data := map[string]K8sResource{
+		"default_web-ipv4":  NewEndpointsFromRuntime(newTestEndpointSlice("web-ipv4", discoveryv1.AddressTypeIPv4, "10.0.0.1", true, "web-1"), CtorConfig{}),
+		"default_web-ipv6":  NewEndpointsFromRuntime(newTestEndpointSlice("web-ipv6", discoveryv1.AddressTypeIPv6, "fd00::1", true, "web-1"), CtorConfig{}),
+		"default_web-other": NewEndpointsFromRuntime(newTestEndpointSlice("web-other", discoveryv1.AddressTypeIPv4, "10.0.0.2", false, "web-2"), CtorConfig{}),
+	}
+	merged := MergeResources(data)
+	require.Len(t, merged, 1)
+	e := merged["default_web"].(*Endpoints)
+	assert.Equal(t, "web", e.Name)
+	assert.Equal(t, []string{"10.0.0.1", "fd00::1"}, e.ReadyIps)
+	assert.Equal(t, []string{"web-1"}, e.ReadyPods)
+	assert.Equal(t, []string{"10.0.0.2"}, e.NotReadyIps)
+	assert.Equal(t, []string{"web-2"}, e.NotReadyPods)
+	assert.Equal(t, []string{"http:8080/TCP"}, e.Ports)
+	assert.Equal(t, []string{"us-east-1a", "hint:us-east-1a"}, e.Zones)
+	assert.Equal(t, map[string]string{"app": "web"}, e.Labels)
+
+	// Merging doesn't modify the stored slices
+	assert.Equal(t, []string{"10.0.0.1"}, data["default_web-ipv4"].(*Endpoints).ReadyIps)
+}
@@ -0,0 +1,124 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Event is the summary of a kubernetes event
+type Event struct {
+	ResourceMeta
+	InvolvedKind      string
+	InvolvedNamespace string
+	InvolvedName      string
+	Reason            string
+	Type              string
+	Count             string
+	LastSeen          time.Time
+	Message           string
+}
+
+// NewEventFromRuntime builds an event from informer result
+func NewEventFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	e := &Event{}
+	e.FromRuntime(obj, config)
+	return e
+}
+
+func getEventLastSeen(event *corev1.Event) time.Time {
+	if event.Series != nil && !event.Series.LastObservedTime.IsZero() {
+		return event.Series.LastObservedTime.Time
+	}
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// FromRuntime builds object from the informer's result
+func (e *Event) FromRuntime(obj interface{}, config CtorConfig) {
+	event := obj.(*corev1.Event)
+	e.FromObjectMeta(event.ObjectMeta, config)
+	e.InvolvedKind = event.InvolvedObject.Kind
+	e.InvolvedNamespace = event.InvolvedObject.Namespace
+	e.InvolvedName = event.InvolvedObject.Name
+	e.Reason = event.Reason
+	e.Type = event.Type
+	count := event.Count
+	if event.Series != nil {
+		count = event.Series.Count
+	}
+	e.Count = strconv.Itoa(int(count))
+	e.LastSeen = getEventLastSeen(event)
+	e.Message = strings.TrimSpace(event.Message)
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (e *Event) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// Summary returns the reason and message of the event without spaces
+func (e *Event) Summary() string {
+	summary := fmt.Sprintf("%s: %s", e.Reason, util.TruncateString(e.Message, 80))
+	return strings.Join(strings.Fields(summary), "_")
+}
+
+// ToStrings serializes the object to strings
+func (e *Event) ToStrings() []string {
+	lst := []string{
+		e.Namespace,
+		e.Name,
+		fmt.Sprintf("%s/%s", e.InvolvedKind, e.InvolvedName),
+		e.Reason,
+		e.Type,
+		e.Count,
+		util.TimeToAge(e.LastSeen),
+		strings.Join(strings.Fields(util.TruncateString(e.Message, 200)), "_"),
+	}
+	return util.DumpLines(lst)
+}
+
+// LastWarnings maps an object to the summary of its most recent warning event
+type LastWarnings map[string]string
+
+func lastWarningKey(kind string, namespace string, name string) string {
+	return fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+}
+
+// Get returns the most recent warning of an object
+func (l LastWarnings) Get(kind string, namespace string, name string) string {
+	return l[lastWarningKey(kind, namespace, name)]
+}
+
+// GetLastWarnings keeps the most recent warning event of every involved object
+func GetLastWarnings(events map[string]K8sResource) LastWarnings {
+	lastSeens := map[string]time.Time{}
+	res := LastWarnings{}
+	for _, r := range events {
+		e, ok := r.(*Event)
+		if !ok || e.Type != corev1.EventTypeWarning {
+			continue
+		}
+		key := lastWarningKey(e.InvolvedKind, e.InvolvedNamespace, e.InvolvedName)
+		if lastSeen, ok := lastSeens[key]; ok && lastSeen.After(e.LastSeen) {
+			continue
+		}
+		lastSeens[key] = e.LastSeen
+		res[key] = e.Summary()
+	}
+	return res
+}
+
+// LastWarningSetter is implemented by resources displaying their most recent warning event
+type LastWarningSetter interface {
+	SetLastWarning(lastWarnings LastWarnings)
+}
@@ -0,0 +1,47 @@
+package resources
+
+import (
+	"strconv"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// IngressClass is the summary of a kubernetes ingress class
+type IngressClass struct {
+	ResourceMeta
+	Controller string
+	IsDefault  string
+}
+
+// NewIngressClassFromRuntime builds an ingress class from informer result
+func NewIngressClassFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	i := &IngressClass{}
+	i.FromRuntime(obj, config)
+	return i
+}
+
+// FromRuntime builds object from the informer's result
+func (i *IngressClass) FromRuntime(obj interface{}, config CtorConfig) {
+	ingressClass := obj.(*networkingv1.IngressClass)
+	i.FromObjectMeta(ingressClass.ObjectMeta, config)
+	i.Controller = ingressClass.Spec.Controller
+	i.IsDefault = strconv.FormatBool(ingressClass.Annotations[networkingv1.AnnotationIsDefaultIngressClass] == "true")
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (i *IngressClass) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (i *IngressClass) ToStrings() []string {
+	lst := []string{
+		i.Name,
+		i.Controller,
+		i.IsDefault,
+		i.resourceAge(),
+		i.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
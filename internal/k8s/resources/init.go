@@ -24,4 +24,18 @@ func init() {
 	gob.Register(&Service{})
 	gob.Register(&ServiceAccount{})
 	gob.Register(&StatefulSet{})
+	gob.Register(&Role{})
+	gob.Register(&RoleBinding{})
+	gob.Register(&ClusterRole{})
+	gob.Register(&ClusterRoleBinding{})
+	gob.Register(&NetworkPolicy{})
+	gob.Register(&PodDisruptionBudget{})
+	gob.Register(&ResourceQuota{})
+	gob.Register(&LimitRange{})
+	gob.Register(&StorageClass{})
+	gob.Register(&IngressClass{})
+	gob.Register(&PriorityClass{})
+	gob.Register(&CSIDriver{})
+	gob.Register(&VolumeAttachment{})
+	gob.Register(&Event{})
 }
@@ -15,6 +15,7 @@ import (
 type K8sResource interface {
 	GetNamespace() string
 	GetLabels() map[string]string
+	GetAnnotations() map[string]string
 	GetFieldSelectors() map[string]string
 
 	HasChanged(k K8sResource) bool
@@ -22,14 +23,56 @@ type K8sResource interface {
 	FromRuntime(obj interface{}, config CtorConfig)
 }
 
+// Merger is implemented by resources split across multiple k8s objects
+type Merger interface {
+	MergeKey() string
+	Merge(k K8sResource) K8sResource
+}
+
+// MergeResources combines resources sharing the same merge key
+func MergeResources(data map[string]K8sResource) map[string]K8sResource {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	res := make(map[string]K8sResource, len(data))
+	for _, key := range keys {
+		r := data[key]
+		merger, ok := r.(Merger)
+		if !ok {
+			res[key] = r
+			continue
+		}
+		mergeKey := merger.MergeKey()
+		if existing, ok := res[mergeKey]; ok {
+			r = existing.(Merger).Merge(r)
+		}
+		res[mergeKey] = r
+	}
+	return res
+}
+
+// DataKeysGetter is implemented by resources exposing the key names of their data
+type DataKeysGetter interface {
+	GetName() string
+	GetNamespace() string
+	GetDataKeys() []string
+}
+
 // ResourceMeta is the generic information of a k8s entity
 type ResourceMeta struct {
 	Name         string
 	Namespace    string // Namespace can be None
 	Labels       map[string]string
+	Annotations  map[string]string
 	CreationTime time.Time
 }
 
+func (r *ResourceMeta) GetName() string {
+	return r.Name
+}
+
 func (r *ResourceMeta) GetNamespace() string {
 	return r.Namespace
 }
@@ -42,11 +85,31 @@ func (r *ResourceMeta) GetLabels() map[string]string {
 	return r.Labels
 }
 
+func (r *ResourceMeta) GetAnnotations() map[string]string {
+	return r.Annotations
+}
+
+// filterAnnotations drops the annotations excluded from the dump
+func filterAnnotations(annotations map[string]string, config CtorConfig) map[string]string {
+	if len(annotations) == 0 {
+		return nil
+	}
+	res := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if config.ExcludedAnnotations[k] {
+			continue
+		}
+		res[k] = v
+	}
+	return res
+}
+
 // FromObjectMeta copies meta information to the object
 func (r *ResourceMeta) FromObjectMeta(meta metav1.ObjectMeta, config CtorConfig) {
 	r.Name = meta.Name
 	r.Namespace = meta.Namespace
 	r.Labels = meta.Labels
+	r.Annotations = filterAnnotations(meta.Annotations, config)
 	r.CreationTime = meta.CreationTimestamp.Time
 }
 
@@ -62,6 +125,9 @@ func (r *ResourceMeta) FromDynamicMeta(u *unstructured.Unstructured, config Ctor
 	if !found {
 		logrus.Debugf("metadata.labels was not found in %#v", u.Object)
 	}
+	annotations, _, err := unstructured.NestedStringMap(u.Object, "metadata", "annotations")
+	util.FatalIf(err)
+	r.Annotations = filterAnnotations(annotations, config)
 	r.CreationTime, err = time.Parse(time.RFC3339, metadata["creationTimestamp"].(string))
 	util.FatalIf(err)
 }
@@ -86,26 +152,80 @@ func (r *ResourceMeta) labelsString() string {
 	return util.JoinSlicesOrNone(els, ",")
 }
 
+// ContainerHeader is the header of the container completion
+const ContainerHeader = "Namespace\tPod\tContainer\tType\tImage\tState\tRestarts"
+
+// DataKeyHeader is the header of the secret and configMap key completion
+const DataKeyHeader = "Namespace\tName\tKey"
+
+// PortForwardTargetHeader is the header of the port-forward target completion
+const PortForwardTargetHeader = "Namespace\tTarget\tPorts"
+
+// PortHeader is the header of the port-forward port completion
+const PortHeader = "Namespace\tTarget\tForward\tName\tPort\tProtocol\tTargetPort"
+
+// Actions proposed when editing the labels or annotations of a resource
+const (
+	TagEditOverwrite = "overwrite"
+	TagEditRemove    = "remove"
+	TagEditAdd       = "add"
+)
+
+// LabelEditHeader is the header of the label completion
+const LabelEditHeader = "Edit\tAction\tOccurrences"
+
+// TaintEditHeader is the header of the taint completion
+const TaintEditHeader = "Taint\tAction\tOccurrences\tSource"
+
+// AnnotationEditHeader is the header of the annotate completion
+const AnnotationEditHeader = "Edit\tAction\tValue"
+
+// SubVerbHeader is the header of the subcommand completion
+const SubVerbHeader = "SubCommand\tResources"
+
+// ContextHeader is the header of the kubeconfig context completion
+const ContextHeader = "Context\tCluster\tUser\tNamespace\tCurrent\tCache"
+
+// MultiResourceHeader is the header of the completion merging multiple resource types
+const MultiResourceHeader = "Type\tNamespace\tName"
+
+// TemplateContainerHeader is the header of the workload's template container completion
+const TemplateContainerHeader = "Namespace\tName\tContainer\tImage"
+
 func ResourceToHeader(r ResourceType) string {
-	replicaSetHeader := "Namespace\tName\tReplicas\tAvailableReplicas\tReadyReplicas\tSelector\tAge\tLabels"
-	apiResourceHeader := "Name\tShortnames\tApiVersion\tNamespaced\tKind"
-	configMapHeader := "Namespace\tName\tAge\tLabels"
+	replicaSetHeader := "Namespace\tName\tReplicas\tAvailableReplicas\tReadyReplicas\tSelector\tContainers\tImages\tGeneration\tStrategy\tStatus\tAge\tLabels"
+	apiResourceHeader := "Name\tShortnames\tApiVersion\tNamespaced\tKind\tCategories"
+	configMapHeader := "Namespace\tName\tKeys\tAge\tLabels"
 	cronJobHeader := "Namespace\tName\tSchedule\tLastSchedule\tContainers\tAge\tLabels"
-	daemonSetHeader := "Namespace\tName\tDesired\tCurrent\tReady\tLabelSelector\tContainers\tAge\tLabels"
-	deploymentHeader := "Namespace\tName\tDesired\tCurrent\tUp-to-date\tAvailable\tAge\tLabels"
-	endpointsHeader := "Namespace\tName\tAge\tReadyIps\tReadyPods\tNotReadyIps\tNotReadyPods\tLabels"
+	daemonSetHeader := "Namespace\tName\tDesired\tCurrent\tReady\tLabelSelector\tContainers\tImages\tGeneration\tStrategy\tStatus\tAge\tLabels"
+	deploymentHeader := "Namespace\tName\tDesired\tCurrent\tUp-to-date\tAvailable\tContainers\tImages\tGeneration\tStrategy\tStatus\tAge\tLabels"
+	endpointsHeader := "Namespace\tName\tAge\tReadyIps\tReadyPods\tNotReadyIps\tNotReadyPods\tPorts\tZones\tLabels"
 	horizontalPodAutoscalerHeader := "Namespace\tName\tReference\tTargets\tMinPods\tMaxPods\tReplicas\tAge\tLabels"
 	ingressHeader := "Namespace\tName\tAddress\tAge\tLabels"
 	jobHeader := "Namespace\tName\tCompletions\tContainers\tAge\tLabels"
 	namespaceHeader := "Name\tAge\tLabels"
-	nodeHeader := "Name\tRoles\tStatus\tInstanceType\tZone\tInternalIp\tTaints\tInstanceID\tAge\tLabels"
-	podHeader := "Namespace\tName\tPodIp\tHostIp\tNodeName\tPhase\tQOSClass\tContainers\tTolerations\tClaims\tAge\tLabels"
+	nodeHeader := "Name\tRoles\tStatus\tUnschedulable\tInstanceType\tZone\tInternalIp\tTaints\tInstanceID\tKubeletVersion\tCpu\tMemory\tPods\tLastWarning\tAge\tLabels"
+	podHeader := "Namespace\tName\tPodIp\tHostIp\tNodeName\tPhase\tQOSClass\tContainers\tTolerations\tClaims\tReady\tRestarts\tOwner\tImages\tRequests\tLimits\tLastWarning\tAge\tLabels"
 	persistentVolumeHeader := "Name\tStatus\tStorageClass\tZone\tClaim\tVolume\tAffinities\tAge\tLabels"
 	persistentVolumeClaimHeader := "Namespace\tName\tStatus\tCapacity\tVolumeName\tStorageClass\tAge\tLabels"
-	secretHeader := "Namespace\tName\tType\tData\tAge\tLabels"
+	secretHeader := "Namespace\tName\tType\tData\tKeys\tAge\tLabels"
 	serviceHeader := "Namespace\tName\tType\tClusterIp\tPorts\tSelector\tAge\tLabels"
 	serviceAccountHeader := "Namespace\tName\tSecrets\tAge\tLabels"
-	statefulSetHeader := "Namespace\tName\tReplicas\tSelector\tAge\tLabels"
+	statefulSetHeader := "Namespace\tName\tReplicas\tSelector\tContainers\tImages\tGeneration\tStrategy\tStatus\tAge\tLabels"
+	roleHeader := "Namespace\tName\tRules\tAge\tLabels"
+	roleBindingHeader := "Namespace\tName\tRole\tSubjects\tAge\tLabels"
+	clusterRoleHeader := "Name\tRules\tAggregationLabels\tAge\tLabels"
+	clusterRoleBindingHeader := "Name\tRole\tSubjects\tAge\tLabels"
+	networkPolicyHeader := "Namespace\tName\tPodSelector\tPolicyTypes\tIngressRules\tEgressRules\tAge\tLabels"
+	podDisruptionBudgetHeader := "Namespace\tName\tMinAvailable\tMaxUnavailable\tHealthy\tAllowedDisruptions\tAge\tLabels"
+	resourceQuotaHeader := "Namespace\tName\tUsed\tAge\tLabels"
+	limitRangeHeader := "Namespace\tName\tLimitTypes\tAge\tLabels"
+	storageClassHeader := "Name\tProvisioner\tReclaimPolicy\tVolumeBindingMode\tAllowVolumeExpansion\tDefault\tAge\tLabels"
+	ingressClassHeader := "Name\tController\tDefault\tAge\tLabels"
+	priorityClassHeader := "Name\tValue\tGlobalDefault\tPreemptionPolicy\tAge\tLabels"
+	csiDriverHeader := "Name\tAttachRequired\tPodInfoOnMount\tStorageCapacity\tModes\tAge\tLabels"
+	volumeAttachmentHeader := "Name\tAttacher\tNode\tPersistentVolume\tAttached\tAge\tLabels"
+	eventHeader := "Namespace\tName\tObject\tReason\tType\tCount\tLastSeen\tMessage"
 	switch r {
 	case ResourceTypeApiResource:
 		return apiResourceHeader
@@ -145,6 +265,34 @@ func ResourceToHeader(r ResourceType) string {
 		return serviceAccountHeader
 	case ResourceTypeStatefulSet:
 		return statefulSetHeader
+	case ResourceTypeRole:
+		return roleHeader
+	case ResourceTypeRoleBinding:
+		return roleBindingHeader
+	case ResourceTypeClusterRole:
+		return clusterRoleHeader
+	case ResourceTypeClusterRoleBinding:
+		return clusterRoleBindingHeader
+	case ResourceTypeNetworkPolicy:
+		return networkPolicyHeader
+	case ResourceTypePodDisruptionBudget:
+		return podDisruptionBudgetHeader
+	case ResourceTypeResourceQuota:
+		return resourceQuotaHeader
+	case ResourceTypeLimitRange:
+		return limitRangeHeader
+	case ResourceTypeStorageClass:
+		return storageClassHeader
+	case ResourceTypeIngressClass:
+		return ingressClassHeader
+	case ResourceTypePriorityClass:
+		return priorityClassHeader
+	case ResourceTypeCSIDriver:
+		return csiDriverHeader
+	case ResourceTypeVolumeAttachment:
+		return volumeAttachmentHeader
+	case ResourceTypeEvent:
+		return eventHeader
 	default:
 		return "Unknown"
 	}
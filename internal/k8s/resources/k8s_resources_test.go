@@ -0,0 +1,23 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExcludedAnnotations(t *testing.T) {
+	meta := metav1.ObjectMeta{
+		Name: "web",
+		Annotations: map[string]string{
+			"kubectl.kubernetes.io/last-applied-configuration": "{\"apiVersion\":\"apps/v1\"}",
+			"deployment.kubernetes.io/revision":                "3",
+		},
+	}
+	r := ResourceMeta{}
+	r.FromObjectMeta(meta, CtorConfig{ExcludedAnnotations: map[string]bool{
+		"kubectl.kubernetes.io/last-applied-configuration": true,
+	}})
+	assert.Equal(t, map[string]string{"deployment.kubernetes.io/revision": "3"}, r.GetAnnotations())
+}
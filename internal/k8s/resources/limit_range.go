@@ -0,0 +1,52 @@
+package resources
+
+import (
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// LimitRange is the summary of a kubernetes limit range
+type LimitRange struct {
+	ResourceMeta
+	LimitTypes []string
+}
+
+// NewLimitRangeFromRuntime builds a limit range from informer result
+func NewLimitRangeFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	l := &LimitRange{}
+	l.FromRuntime(obj, config)
+	return l
+}
+
+// FromRuntime builds object from the informer's result
+func (l *LimitRange) FromRuntime(obj interface{}, config CtorConfig) {
+	limitRange := obj.(*corev1.LimitRange)
+	l.FromObjectMeta(limitRange.ObjectMeta, config)
+	l.LimitTypes = nil
+	for _, limit := range limitRange.Spec.Limits {
+		l.LimitTypes = append(l.LimitTypes, string(limit.Type))
+	}
+}
+
+// GetFieldSelectors returns the fields usable with --field-selector.
+// Limit ranges only support selecting on metadata.
+func (l *LimitRange) GetFieldSelectors() map[string]string {
+	return map[string]string{"metadata.name": l.Name}
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (l *LimitRange) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (l *LimitRange) ToStrings() []string {
+	lst := []string{
+		l.Namespace,
+		l.Name,
+		util.JoinSlicesOrNone(l.LimitTypes, ","),
+		l.resourceAge(),
+		l.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
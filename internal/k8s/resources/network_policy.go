@@ -0,0 +1,63 @@
+package resources
+
+import (
+	"strconv"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+// NetworkPolicy is the summary of a kubernetes network policy
+type NetworkPolicy struct {
+	ResourceMeta
+	PodSelector  []string
+	PolicyTypes  []string
+	IngressRules string
+	EgressRules  string
+}
+
+// NewNetworkPolicyFromRuntime builds a network policy from informer result
+func NewNetworkPolicyFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	n := &NetworkPolicy{}
+	n.FromRuntime(obj, config)
+	return n
+}
+
+// FromRuntime builds object from the informer's result
+func (n *NetworkPolicy) FromRuntime(obj interface{}, config CtorConfig) {
+	networkPolicy := obj.(*networkingv1.NetworkPolicy)
+	n.FromObjectMeta(networkPolicy.ObjectMeta, config)
+	n.PodSelector = labelSelectorToStrings(networkPolicy.Spec.PodSelector)
+	n.PolicyTypes = nil
+	for _, policyType := range networkPolicy.Spec.PolicyTypes {
+		n.PolicyTypes = append(n.PolicyTypes, string(policyType))
+	}
+	n.IngressRules = strconv.Itoa(len(networkPolicy.Spec.Ingress))
+	n.EgressRules = strconv.Itoa(len(networkPolicy.Spec.Egress))
+}
+
+// GetFieldSelectors returns the fields usable with --field-selector.
+// The networking api only registers metadata field selectors for network policies.
+func (n *NetworkPolicy) GetFieldSelectors() map[string]string {
+	return map[string]string{"metadata.name": n.Name}
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (n *NetworkPolicy) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (n *NetworkPolicy) ToStrings() []string {
+	lst := []string{
+		n.Namespace,
+		n.Name,
+		util.JoinSlicesOrNone(n.PodSelector, ","),
+		util.JoinSlicesOrNone(n.PolicyTypes, ","),
+		n.IngressRules,
+		n.EgressRules,
+		n.resourceAge(),
+		n.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
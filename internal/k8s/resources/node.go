@@ -3,6 +3,7 @@ package resources
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
@@ -13,13 +14,30 @@ import (
 // Node is the summary of a kubernetes node
 type Node struct {
 	ResourceMeta
-	Roles        []string
-	Status       string
-	InstanceType string
-	Zone         string
-	InstanceID   string
-	InternalIP   string
-	Taints       []string
+	Roles           []string
+	Status          string
+	InstanceType    string
+	Zone            string
+	InstanceID      string
+	InternalIP      string
+	Taints          []string
+	Unschedulable   string
+	KubeletVersion  string
+	AllocatableCpu  string
+	AllocatableMem  string
+	AllocatablePods string
+	Pods            string // Joined from pods during completion
+	LastWarning     string // Joined from events during completion
+}
+
+// getLabelWithFallback returns the value of the first label present
+func getLabelWithFallback(labels map[string]string, keys ...string) string {
+	for _, key := range keys {
+		if v, ok := labels[key]; ok {
+			return v
+		}
+	}
+	return ""
 }
 
 // NewNodeFromRuntime builds a k8sresoutce from informer result
@@ -72,8 +90,14 @@ func (n *Node) FromRuntime(obj interface{}, config CtorConfig) {
 		n.Taints = append(n.Taints, taint)
 	}
 
-	n.InstanceType = n.Labels["beta.kubernetes.io/instance-type"]
-	n.Zone = n.Labels["failure-domain.beta.kubernetes.io/zone"]
+	n.InstanceType = getLabelWithFallback(n.Labels, corev1.LabelInstanceTypeStable, corev1.LabelInstanceType)
+	n.Zone = getLabelWithFallback(n.Labels, corev1.LabelTopologyZone, corev1.LabelFailureDomainBetaZone)
+	n.Unschedulable = strconv.FormatBool(node.Spec.Unschedulable)
+	n.KubeletVersion = node.Status.NodeInfo.KubeletVersion
+	allocatable := node.Status.Allocatable
+	n.AllocatableCpu = allocatable.Cpu().String()
+	n.AllocatableMem = allocatable.Memory().String()
+	n.AllocatablePods = allocatable.Pods().String()
 	for _, v := range node.Status.Addresses {
 		if v.Type == "InternalIP" {
 			n.InternalIP = v.Address
@@ -87,17 +111,33 @@ func (n *Node) HasChanged(k K8sResource) bool {
 	return true
 }
 
+// SetLastWarning sets the most recent warning event of the node
+func (n *Node) SetLastWarning(lastWarnings LastWarnings) {
+	n.LastWarning = lastWarnings.Get("Node", "", n.Name)
+}
+
+// SetPodCount sets the number of pods scheduled on the node
+func (n *Node) SetPodCount(podCounts map[string]int) {
+	n.Pods = fmt.Sprintf("%d/%s", podCounts[n.Name], n.AllocatablePods)
+}
+
 // ToString serializes the object to strings
 func (n *Node) ToStrings() []string {
 	line := []string{
 		n.Name,
 		util.JoinSlicesOrNone(n.Roles, ","),
 		n.Status,
+		n.Unschedulable,
 		n.InstanceType,
 		n.Zone,
 		n.InternalIP,
 		util.JoinSlicesOrNone(n.Taints, ","),
 		n.InstanceID,
+		n.KubeletVersion,
+		n.AllocatableCpu,
+		n.AllocatableMem,
+		n.Pods,
+		n.LastWarning,
 		n.resourceAge(),
 		n.labelsString(),
 	}
@@ -0,0 +1,42 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNodeFromRuntime(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "node-1",
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone":            "us-east-1a",
+				"failure-domain.beta.kubernetes.io/zone": "us-east-1b",
+				"beta.kubernetes.io/instance-type":       "m5.large",
+			},
+		},
+		Spec: corev1.NodeSpec{Unschedulable: true},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{KubeletVersion: "v1.25.4"},
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1930m"),
+				corev1.ResourceMemory: resource.MustParse("7Gi"),
+				corev1.ResourcePods:   resource.MustParse("29"),
+			},
+		},
+	}
+	n := NewNodeFromRuntime(node, CtorConfig{}).(*Node)
+	assert.Equal(t, "us-east-1a", n.Zone)
+	assert.Equal(t, "m5.large", n.InstanceType)
+	assert.Equal(t, "true", n.Unschedulable)
+	assert.Equal(t, "v1.25.4", n.KubeletVersion)
+	assert.Equal(t, "1930m", n.AllocatableCpu)
+	assert.Equal(t, "7Gi", n.AllocatableMem)
+
+	n.SetPodCount(map[string]int{"node-1": 12})
+	assert.Equal(t, "12/29", n.Pods)
+}
@@ -2,11 +2,16 @@ package resources
 
 import (
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
 	"github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Pod is the summary of a kubernetes pod
@@ -21,6 +26,79 @@ type Pod struct {
 	Phase       string
 	QosClass    string
 	Resource    string
+	Ready       string
+	Restarts    string
+	Owner       string
+	Images      []string
+	Requests    string
+	Limits      string
+	LastWarning string // Joined from events during completion
+
+	ContainerInfos []ContainerInfo
+	ContainerPorts []PortInfo
+}
+
+// ContainerInfo is the summary of a container of a pod
+type ContainerInfo struct {
+	Name     string
+	Type     string // container, init or ephemeral
+	Image    string
+	State    string
+	Restarts string
+}
+
+func containerInfosEqual(a []ContainerInfo, b []ContainerInfo) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+	return true
+}
+
+func getContainerState(status corev1.ContainerStatus) string {
+	switch {
+	case status.State.Running != nil:
+		return "Running"
+	case status.State.Waiting != nil:
+		return fmt.Sprintf("Waiting:%s", status.State.Waiting.Reason)
+	case status.State.Terminated != nil:
+		return fmt.Sprintf("Terminated:%s", status.State.Terminated.Reason)
+	}
+	return "Unknown"
+}
+
+func getContainerInfos(pod *corev1.Pod) []ContainerInfo {
+	statuses := map[string]corev1.ContainerStatus{}
+	allStatuses := append(append(append([]corev1.ContainerStatus{},
+		pod.Status.ContainerStatuses...),
+		pod.Status.InitContainerStatuses...),
+		pod.Status.EphemeralContainerStatuses...)
+	for _, status := range allStatuses {
+		statuses[status.Name] = status
+	}
+	res := []ContainerInfo{}
+	addContainer := func(name string, containerType string, image string) {
+		c := ContainerInfo{Name: name, Type: containerType, Image: image, State: "Unknown", Restarts: "0"}
+		if status, ok := statuses[name]; ok {
+			c.State = getContainerState(status)
+			c.Restarts = strconv.Itoa(int(status.RestartCount))
+		}
+		res = append(res, c)
+	}
+	for _, c := range pod.Spec.Containers {
+		addContainer(c.Name, "container", c.Image)
+	}
+	for _, c := range pod.Spec.InitContainers {
+		addContainer(c.Name, "init", c.Image)
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		addContainer(c.Name, "ephemeral", c.Image)
+	}
+	return res
 }
 
 func getPhase(p *corev1.Pod) string {
@@ -51,6 +129,72 @@ func getPhase(p *corev1.Pod) string {
 	return string(p.Status.Phase)
 }
 
+func getReady(p *corev1.Pod) string {
+	ready := 0
+	for _, v := range p.Status.ContainerStatuses {
+		if v.Ready {
+			ready++
+		}
+	}
+	return fmt.Sprintf("%d/%d", ready, len(p.Spec.Containers))
+}
+
+func getRestarts(p *corev1.Pod) string {
+	restarts := 0
+	for _, v := range p.Status.ContainerStatuses {
+		restarts += int(v.RestartCount)
+	}
+	return strconv.Itoa(restarts)
+}
+
+// getOwner returns the controller of the pod. Pods created by a deployment
+// are owned by a replicaset suffixed by the pod template hash, the
+// deployment is returned instead.
+func getOwner(meta metav1.ObjectMeta) string {
+	owner := metav1.GetControllerOf(&meta)
+	if owner == nil {
+		return ""
+	}
+	if owner.Kind == "ReplicaSet" {
+		hash := meta.Labels[appsv1.DefaultDeploymentUniqueLabelKey]
+		if hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+			return fmt.Sprintf("Deployment/%s", strings.TrimSuffix(owner.Name, "-"+hash))
+		}
+	}
+	return fmt.Sprintf("%s/%s", owner.Kind, owner.Name)
+}
+
+// getShortImage removes the registry and repository path of the image
+func getShortImage(image string) string {
+	return util.LastURLPart(image)
+}
+
+// resourceListToString sums the resources of the containers
+func resourceListToString(resourceLists []corev1.ResourceList) string {
+	total := corev1.ResourceList{}
+	for _, resourceList := range resourceLists {
+		for name, quantity := range resourceList {
+			sum, ok := total[name]
+			if !ok {
+				sum = resource.Quantity{}
+			}
+			sum.Add(quantity)
+			total[name] = sum
+		}
+	}
+	res := make([]string, 0, len(total))
+	for name, quantity := range total {
+		res = append(res, fmt.Sprintf("%s=%s", name, quantity.String()))
+	}
+	sort.Strings(res)
+	return strings.Join(res, ",")
+}
+
+// GetPorts returns the ports declared by the containers of the pod
+func (p *Pod) GetPorts() []PortInfo {
+	return p.ContainerPorts
+}
+
 // NewPodFromRuntime builds a pod from informer result
 func NewPodFromRuntime(obj interface{}, config CtorConfig) K8sResource {
 	p := &Pod{}
@@ -69,6 +213,22 @@ func (p *Pod) FromRuntime(obj interface{}, config CtorConfig) {
 	p.NodeName = spec.NodeName
 	p.Phase = getPhase(pod)
 	p.QosClass = string(pod.Status.QOSClass)
+	p.Ready = getReady(pod)
+	p.Restarts = getRestarts(pod)
+	p.Owner = getOwner(pod.ObjectMeta)
+	p.ContainerInfos = getContainerInfos(pod)
+	p.ContainerPorts = getContainerPorts(spec.Containers)
+
+	requests := make([]corev1.ResourceList, 0, len(spec.Containers))
+	limits := make([]corev1.ResourceList, 0, len(spec.Containers))
+	p.Images = make([]string, 0, len(spec.Containers))
+	for _, v := range spec.Containers {
+		p.Images = append(p.Images, getShortImage(v.Image))
+		requests = append(requests, v.Resources.Requests)
+		limits = append(limits, v.Resources.Limits)
+	}
+	p.Requests = resourceListToString(requests)
+	p.Limits = resourceListToString(limits)
 
 	containers := spec.Containers
 	containers = append(containers, spec.InitContainers...)
@@ -105,11 +265,23 @@ func (p *Pod) FromRuntime(obj interface{}, config CtorConfig) {
 
 // HasChanged returns true if the resource's dump needs to be updated
 func (p *Pod) HasChanged(k K8sResource) bool {
-	oldPod := k.(*Pod)
+	oldPod, ok := k.(*Pod)
+	if !ok {
+		return true
+	}
 	return (p.PodIP != oldPod.PodIP ||
 		p.Phase != oldPod.Phase ||
-		util.StringMapsEqual(p.Labels, oldPod.Labels) ||
-		p.NodeName != oldPod.NodeName)
+		!util.StringMapsEqual(p.Labels, oldPod.Labels) ||
+		!util.StringMapsEqual(p.Annotations, oldPod.Annotations) ||
+		p.NodeName != oldPod.NodeName ||
+		p.Ready != oldPod.Ready ||
+		p.Restarts != oldPod.Restarts ||
+		p.Owner != oldPod.Owner ||
+		p.Requests != oldPod.Requests ||
+		p.Limits != oldPod.Limits ||
+		!util.StringSlicesEqual(p.Images, oldPod.Images) ||
+		!containerInfosEqual(p.ContainerInfos, oldPod.ContainerInfos) ||
+		!portInfosEqual(p.ContainerPorts, oldPod.ContainerPorts))
 }
 
 func (p *Pod) GetFieldSelectors() map[string]string {
@@ -118,6 +290,11 @@ func (p *Pod) GetFieldSelectors() map[string]string {
 		"status.phase":  p.Phase}
 }
 
+// SetLastWarning sets the most recent warning event of the pod
+func (p *Pod) SetLastWarning(lastWarnings LastWarnings) {
+	p.LastWarning = lastWarnings.Get("Pod", p.Namespace, p.Name)
+}
+
 // ToString serializes the object to strings
 func (p *Pod) ToStrings() []string {
 	lst := []string{
@@ -131,8 +308,33 @@ func (p *Pod) ToStrings() []string {
 		util.TruncateString(util.JoinSlicesOrNone(p.Containers, ","), 300),
 		util.JoinSlicesOrNone(p.Tolerations, ","),
 		util.JoinSlicesOrNone(p.Claims, ","),
+		p.Ready,
+		p.Restarts,
+		p.Owner,
+		util.TruncateString(util.JoinSlicesOrNone(p.Images, ","), 300),
+		p.Requests,
+		p.Limits,
+		p.LastWarning,
 		p.resourceAge(),
 		p.labelsString(),
 	}
 	return util.DumpLines(lst)
 }
+
+// ContainersToStrings serializes the containers of the pod to strings
+func (p *Pod) ContainersToStrings() []string {
+	res := []string{}
+	for _, c := range p.ContainerInfos {
+		lst := []string{
+			p.Namespace,
+			p.Name,
+			c.Name,
+			c.Type,
+			c.Image,
+			c.State,
+			c.Restarts,
+		}
+		res = append(res, util.DumpLines(lst)...)
+	}
+	return res
+}
@@ -0,0 +1,67 @@
+package resources
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	policyv1 "k8s.io/api/policy/v1"
+)
+
+// PodDisruptionBudget is the summary of a kubernetes pod disruption budget
+type PodDisruptionBudget struct {
+	ResourceMeta
+	MinAvailable       string
+	MaxUnavailable     string
+	Healthy            string
+	AllowedDisruptions string
+}
+
+// NewPodDisruptionBudgetFromRuntime builds a pod disruption budget from informer result
+func NewPodDisruptionBudgetFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	p := &PodDisruptionBudget{}
+	p.FromRuntime(obj, config)
+	return p
+}
+
+// FromRuntime builds object from the informer's result
+func (p *PodDisruptionBudget) FromRuntime(obj interface{}, config CtorConfig) {
+	pdb := obj.(*policyv1.PodDisruptionBudget)
+	p.FromObjectMeta(pdb.ObjectMeta, config)
+	p.MinAvailable = ""
+	if pdb.Spec.MinAvailable != nil {
+		p.MinAvailable = pdb.Spec.MinAvailable.String()
+	}
+	p.MaxUnavailable = ""
+	if pdb.Spec.MaxUnavailable != nil {
+		p.MaxUnavailable = pdb.Spec.MaxUnavailable.String()
+	}
+	p.Healthy = fmt.Sprintf("%d/%d", pdb.Status.CurrentHealthy, pdb.Status.DesiredHealthy)
+	p.AllowedDisruptions = strconv.Itoa(int(pdb.Status.DisruptionsAllowed))
+}
+
+// GetFieldSelectors returns the fields usable with --field-selector.
+// The policy api rejects selectors on spec and status fields of pdbs.
+func (p *PodDisruptionBudget) GetFieldSelectors() map[string]string {
+	return map[string]string{"metadata.name": p.Name}
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (p *PodDisruptionBudget) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (p *PodDisruptionBudget) ToStrings() []string {
+	lst := []string{
+		p.Namespace,
+		p.Name,
+		p.MinAvailable,
+		p.MaxUnavailable,
+		p.Healthy,
+		p.AllowedDisruptions,
+		p.resourceAge(),
+		p.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPodFromRuntime(t *testing.T) {
+	isController := true
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      "web-5d9c7b8f4-x2x7k",
+			Labels:    map[string]string{"pod-template-hash": "5d9c7b8f4"},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: "web-5d9c7b8f4", Controller: &isController},
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:  "web",
+					Image: "registry.example.com/team/web:v1.42",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("100m"),
+							corev1.ResourceMemory: resource.MustParse("64Mi"),
+						},
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("128Mi")},
+					},
+				},
+				{
+					Name:  "proxy",
+					Image: "envoy:v1.25",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("50m")},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{
+				{Name: "web", Ready: true, RestartCount: 3},
+				{Name: "proxy", Ready: false, RestartCount: 1},
+			},
+		},
+	}
+	p := NewPodFromRuntime(pod, CtorConfig{}).(*Pod)
+	assert.Equal(t, "1/2", p.Ready)
+	assert.Equal(t, "4", p.Restarts)
+	assert.Equal(t, "Deployment/web", p.Owner)
+	assert.Equal(t, []string{"web:v1.42", "envoy:v1.25"}, p.Images)
+	assert.Equal(t, "cpu=150m,memory=64Mi", p.Requests)
+	assert.Equal(t, "memory=128Mi", p.Limits)
+
+	pod.Status.ContainerStatuses[0].RestartCount = 4
+	assert.True(t, NewPodFromRuntime(pod, CtorConfig{}).HasChanged(p))
+	assert.False(t, p.HasChanged(p))
+
+	p = NewPodFromRuntime(pod, CtorConfig{}).(*Pod)
+	updated := NewPodFromRuntime(pod, CtorConfig{}).(*Pod)
+	assert.False(t, updated.HasChanged(p))
+	updated.ContainerInfos[1].State = "Running"
+	assert.True(t, updated.HasChanged(p))
+	updated = NewPodFromRuntime(pod, CtorConfig{}).(*Pod)
+	updated.ContainerPorts = []PortInfo{{Name: "http", Port: 8080, Protocol: "TCP", Target: "web"}}
+	assert.True(t, updated.HasChanged(p))
+	updated = NewPodFromRuntime(pod, CtorConfig{}).(*Pod)
+	updated.Owner = "StatefulSet/web"
+	assert.True(t, updated.HasChanged(p))
+}
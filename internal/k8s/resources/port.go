@@ -0,0 +1,48 @@
+package resources
+
+import (
+	corev1 "k8s.io/api/core/v1"
+)
+
+// PortInfo is a port of a service or a container
+type PortInfo struct {
+	Name     string
+	Port     int32
+	Protocol string
+	Target   string // Target port for a service, container name for a container port
+}
+
+// PortsGetter is implemented by resources which can be port-forwarded
+type PortsGetter interface {
+	GetName() string
+	GetNamespace() string
+	GetPorts() []PortInfo
+}
+
+// getContainerPorts returns the ports declared by the containers
+func getContainerPorts(containers []corev1.Container) []PortInfo {
+	res := []PortInfo{}
+	for _, c := range containers {
+		for _, p := range c.Ports {
+			res = append(res, PortInfo{
+				Name:     p.Name,
+				Port:     p.ContainerPort,
+				Protocol: string(p.Protocol),
+				Target:   c.Name,
+			})
+		}
+	}
+	return res
+}
+
+func portInfosEqual(a []PortInfo, b []PortInfo) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range a {
+		if a[k] != b[k] {
+			return false
+		}
+	}
+	return true
+}
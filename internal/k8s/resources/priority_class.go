@@ -0,0 +1,53 @@
+package resources
+
+import (
+	"strconv"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+)
+
+// PriorityClass is the summary of a kubernetes priority class
+type PriorityClass struct {
+	ResourceMeta
+	Value            string
+	GlobalDefault    string
+	PreemptionPolicy string
+}
+
+// NewPriorityClassFromRuntime builds a priority class from informer result
+func NewPriorityClassFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	p := &PriorityClass{}
+	p.FromRuntime(obj, config)
+	return p
+}
+
+// FromRuntime builds object from the informer's result
+func (p *PriorityClass) FromRuntime(obj interface{}, config CtorConfig) {
+	priorityClass := obj.(*schedulingv1.PriorityClass)
+	p.FromObjectMeta(priorityClass.ObjectMeta, config)
+	p.Value = strconv.Itoa(int(priorityClass.Value))
+	p.GlobalDefault = strconv.FormatBool(priorityClass.GlobalDefault)
+	p.PreemptionPolicy = ""
+	if priorityClass.PreemptionPolicy != nil {
+		p.PreemptionPolicy = string(*priorityClass.PreemptionPolicy)
+	}
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (p *PriorityClass) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (p *PriorityClass) ToStrings() []string {
+	lst := []string{
+		p.Name,
+		p.Value,
+		p.GlobalDefault,
+		p.PreemptionPolicy,
+		p.resourceAge(),
+		p.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
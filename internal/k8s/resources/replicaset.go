@@ -5,6 +5,7 @@ import (
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // ReplicaSet is the summary of a kubernetes replicaSet
@@ -14,6 +15,27 @@ type ReplicaSet struct {
 	ReadyReplicas     string
 	AvailableReplicas string
 	Selectors         []string
+	WorkloadTemplate
+}
+
+func getReplicaSetRolloutStatus(replicaSet *appsv1.ReplicaSet) string {
+	for _, c := range replicaSet.Status.Conditions {
+		if c.Type == appsv1.ReplicaSetReplicaFailure && c.Status == corev1.ConditionTrue {
+			return RolloutStalled
+		}
+	}
+	status := replicaSet.Status
+	if status.ObservedGeneration < replicaSet.Generation {
+		return RolloutProgressing
+	}
+	desired := int32(1)
+	if replicaSet.Spec.Replicas != nil {
+		desired = *replicaSet.Spec.Replicas
+	}
+	if status.ReadyReplicas < desired || status.Replicas != desired {
+		return RolloutProgressing
+	}
+	return RolloutComplete
 }
 
 // NewReplicaSetFromRuntime builds a k8sresource from informer result
@@ -32,16 +54,25 @@ func (r *ReplicaSet) FromRuntime(obj interface{}, config CtorConfig) {
 	r.AvailableReplicas = strconv.Itoa(int(replicaSet.Status.AvailableReplicas))
 	r.Selectors = util.JoinStringMap(replicaSet.Spec.Selector.MatchLabels,
 		ExcludedLabels, "=")
+
+	r.fromPodTemplate(replicaSet.Spec.Template)
+	r.setGeneration(replicaSet.Status.ObservedGeneration, replicaSet.Generation)
+	r.RolloutStatus = getReplicaSetRolloutStatus(replicaSet)
 }
 
 // HasChanged returns true if the resource'r dump needs to be updated
 func (r *ReplicaSet) HasChanged(k K8sResource) bool {
-	oldRs := k.(*ReplicaSet)
+	oldRs, ok := k.(*ReplicaSet)
+	if !ok {
+		return true
+	}
 	return (r.Replicas != oldRs.Replicas ||
 		r.ReadyReplicas != oldRs.ReadyReplicas ||
 		r.AvailableReplicas != oldRs.AvailableReplicas ||
-		util.StringSlicesEqual(r.Selectors, oldRs.Selectors) ||
-		util.StringMapsEqual(r.Labels, oldRs.Labels))
+		!util.StringSlicesEqual(r.Selectors, oldRs.Selectors) ||
+		!util.StringMapsEqual(r.Labels, oldRs.Labels) ||
+		!util.StringMapsEqual(r.Annotations, oldRs.Annotations) ||
+		r.WorkloadTemplate.hasChanged(&oldRs.WorkloadTemplate))
 }
 
 // ToString serializes the object to strings
@@ -54,8 +85,8 @@ func (r *ReplicaSet) ToStrings() []string {
 		r.AvailableReplicas,
 		r.ReadyReplicas,
 		selectorList,
-		r.resourceAge(),
-		r.labelsString(),
 	}
+	line = append(line, r.WorkloadTemplate.toStrings()...)
+	line = append(line, r.resourceAge(), r.labelsString())
 	return util.DumpLines(line)
 }
@@ -0,0 +1,61 @@
+package resources
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// ResourceQuota is the summary of a kubernetes resource quota
+type ResourceQuota struct {
+	ResourceMeta
+	Usages []string
+}
+
+// NewResourceQuotaFromRuntime builds a resource quota from informer result
+func NewResourceQuotaFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	r := &ResourceQuota{}
+	r.FromRuntime(obj, config)
+	return r
+}
+
+// FromRuntime builds object from the informer's result
+func (r *ResourceQuota) FromRuntime(obj interface{}, config CtorConfig) {
+	resourceQuota := obj.(*corev1.ResourceQuota)
+	r.FromObjectMeta(resourceQuota.ObjectMeta, config)
+	r.Usages = nil
+	for name, hard := range resourceQuota.Status.Hard {
+		used, ok := resourceQuota.Status.Used[name]
+		usedStr := "0"
+		if ok {
+			usedStr = used.String()
+		}
+		r.Usages = append(r.Usages, fmt.Sprintf("%s=%s/%s", name, usedStr, hard.String()))
+	}
+	sort.Strings(r.Usages)
+}
+
+// GetFieldSelectors returns the fields usable with --field-selector.
+// Unlike pods, resource quotas don't have spec or status field selectors.
+func (r *ResourceQuota) GetFieldSelectors() map[string]string {
+	return map[string]string{"metadata.name": r.Name}
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (r *ResourceQuota) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (r *ResourceQuota) ToStrings() []string {
+	lst := []string{
+		r.Namespace,
+		r.Name,
+		util.JoinSlicesOrNone(r.Usages, ","),
+		r.resourceAge(),
+		r.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
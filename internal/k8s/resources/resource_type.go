@@ -2,6 +2,8 @@ package resources
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 )
@@ -36,6 +38,20 @@ const (
 	ResourceTypeService
 	ResourceTypeServiceAccount
 	ResourceTypeStatefulSet
+	ResourceTypeRole
+	ResourceTypeRoleBinding
+	ResourceTypeClusterRole
+	ResourceTypeClusterRoleBinding
+	ResourceTypeNetworkPolicy
+	ResourceTypePodDisruptionBudget
+	ResourceTypeResourceQuota
+	ResourceTypeLimitRange
+	ResourceTypeStorageClass
+	ResourceTypeIngressClass
+	ResourceTypePriorityClass
+	ResourceTypeCSIDriver
+	ResourceTypeVolumeAttachment
+	ResourceTypeEvent
 	ResourceTypeUnknown
 )
 
@@ -79,6 +95,34 @@ func (r ResourceType) IsNamespaced() bool {
 		return false
 	case ResourceTypeIngress:
 		return true
+	case ResourceTypeRole:
+		return true
+	case ResourceTypeRoleBinding:
+		return true
+	case ResourceTypeClusterRole:
+		return false
+	case ResourceTypeClusterRoleBinding:
+		return false
+	case ResourceTypeNetworkPolicy:
+		return true
+	case ResourceTypePodDisruptionBudget:
+		return true
+	case ResourceTypeResourceQuota:
+		return true
+	case ResourceTypeLimitRange:
+		return true
+	case ResourceTypeStorageClass:
+		return false
+	case ResourceTypeIngressClass:
+		return false
+	case ResourceTypePriorityClass:
+		return false
+	case ResourceTypeCSIDriver:
+		return false
+	case ResourceTypeVolumeAttachment:
+		return false
+	case ResourceTypeEvent:
+		return true
 	}
 	return false
 }
@@ -123,6 +167,34 @@ func (r ResourceType) String() string {
 		return "serviceaccounts"
 	case ResourceTypeStatefulSet:
 		return "statefulsets"
+	case ResourceTypeRole:
+		return "roles"
+	case ResourceTypeRoleBinding:
+		return "rolebindings"
+	case ResourceTypeClusterRole:
+		return "clusterroles"
+	case ResourceTypeClusterRoleBinding:
+		return "clusterrolebindings"
+	case ResourceTypeNetworkPolicy:
+		return "networkpolicies"
+	case ResourceTypePodDisruptionBudget:
+		return "poddisruptionbudgets"
+	case ResourceTypeResourceQuota:
+		return "resourcequotas"
+	case ResourceTypeLimitRange:
+		return "limitranges"
+	case ResourceTypeStorageClass:
+		return "storageclasses"
+	case ResourceTypeIngressClass:
+		return "ingressclasses"
+	case ResourceTypePriorityClass:
+		return "priorityclasses"
+	case ResourceTypeCSIDriver:
+		return "csidrivers"
+	case ResourceTypeVolumeAttachment:
+		return "volumeattachments"
+	case ResourceTypeEvent:
+		return "events"
 	}
 	return "unknown"
 }
@@ -233,10 +305,110 @@ func ParseResourceType(s string) ResourceType {
 		fallthrough
 	case "ingresses":
 		return ResourceTypeIngress
+	case "role":
+		fallthrough
+	case "roles":
+		return ResourceTypeRole
+	case "rolebinding":
+		fallthrough
+	case "rolebindings":
+		return ResourceTypeRoleBinding
+	case "clusterrole":
+		fallthrough
+	case "clusterroles":
+		return ResourceTypeClusterRole
+	case "clusterrolebinding":
+		fallthrough
+	case "clusterrolebindings":
+		return ResourceTypeClusterRoleBinding
+	case "netpol":
+		fallthrough
+	case "networkpolicy":
+		fallthrough
+	case "networkpolicies":
+		return ResourceTypeNetworkPolicy
+	case "pdb":
+		fallthrough
+	case "poddisruptionbudget":
+		fallthrough
+	case "poddisruptionbudgets":
+		return ResourceTypePodDisruptionBudget
+	case "quota":
+		fallthrough
+	case "resourcequota":
+		fallthrough
+	case "resourcequotas":
+		return ResourceTypeResourceQuota
+	case "limits":
+		fallthrough
+	case "limitrange":
+		fallthrough
+	case "limitranges":
+		return ResourceTypeLimitRange
+	case "sc":
+		fallthrough
+	case "storageclass":
+		fallthrough
+	case "storageclasses":
+		return ResourceTypeStorageClass
+	case "ingressclass":
+		fallthrough
+	case "ingressclasses":
+		return ResourceTypeIngressClass
+	case "pc":
+		fallthrough
+	case "priorityclass":
+		fallthrough
+	case "priorityclasses":
+		return ResourceTypePriorityClass
+	case "csidriver":
+		fallthrough
+	case "csidrivers":
+		return ResourceTypeCSIDriver
+	case "volumeattachment":
+		fallthrough
+	case "volumeattachments":
+		return ResourceTypeVolumeAttachment
+	case "ev":
+		fallthrough
+	case "event":
+		fallthrough
+	case "events":
+		return ResourceTypeEvent
+	}
+	if name, ok := stripApiGroup(s); ok {
+		return ParseResourceType(name)
 	}
 	return ResourceTypeUnknown
 }
 
+// apiGroups are the groups of the supported resource types
+var apiGroups = []string{"apps", "batch", "autoscaling", "extensions", "policy",
+	"networking.k8s.io", "rbac.authorization.k8s.io", "storage.k8s.io",
+	"scheduling.k8s.io", "discovery.k8s.io", "events.k8s.io"}
+
+var apiVersionRegexp = regexp.MustCompile(`^v[0-9]+((alpha|beta)[0-9]+)?$`)
+
+// stripApiGroup removes the group qualifying a resource type, like in
+// deployments.apps or deployments.v1.apps. Only known groups are removed
+// so names like svc.foo are not considered as a type.
+func stripApiGroup(s string) (string, bool) {
+	name, qualifier, found := strings.Cut(s, ".")
+	if !found {
+		return s, false
+	}
+	version, group, found := strings.Cut(qualifier, ".")
+	if found && apiVersionRegexp.MatchString(version) {
+		qualifier = group
+	}
+	for _, apiGroup := range apiGroups {
+		if qualifier == apiGroup {
+			return name, true
+		}
+	}
+	return s, false
+}
+
 func GetResourceSetFromSlice(resourceSlice []string) (map[ResourceType]bool, error) {
 	res := make(map[ResourceType]bool, 0)
 	for _, resourceStr := range resourceSlice {
@@ -249,25 +421,94 @@ func GetResourceSetFromSlice(resourceSlice []string) (map[ResourceType]bool, err
 	return res, nil
 }
 
+// ParseResourceTypeList parses a comma separated list of resource types
+// like pods,svc. Nil is returned if one of the types is unknown.
+func ParseResourceTypeList(s string) []ResourceType {
+	res := []ResourceType{}
+	for _, resourceStr := range strings.Split(s, ",") {
+		r := ParseResourceType(resourceStr)
+		if r == ResourceTypeUnknown {
+			return nil
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+// ParseTypeName parses a type/name argument like deploy/coredns. The name
+// can be empty when it's being completed.
+func ParseTypeName(s string) (ResourceType, string, bool) {
+	typeStr, name, found := strings.Cut(s, "/")
+	if !found || strings.HasPrefix(s, "-") {
+		return ResourceTypeUnknown, "", false
+	}
+	r := ParseResourceType(typeStr)
+	if r == ResourceTypeUnknown {
+		return ResourceTypeUnknown, "", false
+	}
+	return r, name, true
+}
+
+// IsResourceTypeListPrefix returns true if the argument is a list of
+// resource types being completed, like pods,
+func IsResourceTypeListPrefix(s string) bool {
+	i := strings.LastIndex(s, ",")
+	if i <= 0 || strings.HasPrefix(s, "-") || strings.Contains(s, "/") {
+		return false
+	}
+	return ParseResourceTypeList(s[:i]) != nil
+}
+
+// GetResourceTypes returns the resource types provided in the arguments,
+// either as type/name arguments or as a comma separated list
+func GetResourceTypes(args []string) []ResourceType {
+	res := []ResourceType{}
+	for _, arg := range args {
+		if r, _, ok := ParseTypeName(arg); ok {
+			if !IsResourceTypeIn(r, res) {
+				res = append(res, r)
+			}
+			continue
+		}
+		if len(res) > 0 {
+			continue
+		}
+		if resourceTypes := ParseResourceTypeList(arg); resourceTypes != nil {
+			return resourceTypes
+		}
+	}
+	return res
+}
+
+// IsResourceTypeIn returns true if the resource type is in the list
+func IsResourceTypeIn(r ResourceType, resourceTypes []ResourceType) bool {
+	for _, resourceType := range resourceTypes {
+		if resourceType == r {
+			return true
+		}
+	}
+	return false
+}
+
 func GetResourceType(cmdUse string, args []string) ResourceType {
 	logrus.Debugf("Getting resource type from %s, '%s', %d", cmdUse, args, len(args))
 	resourceType := ResourceTypeApiResource
-	if cmdUse == "logs" {
-		return ResourceTypePod
+	if len(args) == 0 {
+		return resourceType
 	}
-	if cmdUse == "exec" {
-		return ResourceTypePod
+	lastArg := args[len(args)-1]
+	// The name of a type/name argument is being completed
+	if r, _, ok := ParseTypeName(lastArg); ok {
+		return r
 	}
-	// No resource type or we have only
+	// No resource type, the resource types are being completed or we have only
 	// get ''#
-	if len(args) <= 1 {
+	if len(args) <= 1 || IsResourceTypeListPrefix(lastArg) {
 		return resourceType
 	}
-	for _, arg := range args {
-		resourceType = ParseResourceType(arg)
-		if resourceType != ResourceTypeUnknown {
-			return resourceType
-		}
+	resourceTypes := GetResourceTypes(args)
+	if len(resourceTypes) == 0 {
+		return ResourceTypeUnknown
 	}
-	return ResourceTypeUnknown
+	return resourceTypes[0]
 }
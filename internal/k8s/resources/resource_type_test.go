@@ -17,6 +17,23 @@ func TestParseResourceType(t *testing.T) {
 		{"pod", ResourceTypePod},
 		{"statefulsets", ResourceTypeStatefulSet},
 		{"sts", ResourceTypeStatefulSet},
+		{"rolebinding", ResourceTypeRoleBinding},
+		{"clusterroles", ResourceTypeClusterRole},
+		{"netpol", ResourceTypeNetworkPolicy},
+		{"pdb", ResourceTypePodDisruptionBudget},
+		{"quota", ResourceTypeResourceQuota},
+		{"limits", ResourceTypeLimitRange},
+		{"deployments.apps", ResourceTypeDeployment},
+		{"events.events.k8s.io", ResourceTypeEvent},
+		{"unknown.apps", ResourceTypeUnknown},
+		{"deployments.v1.apps", ResourceTypeDeployment},
+		{"networkpolicies.networking.k8s.io", ResourceTypeNetworkPolicy},
+		{"svc.foo", ResourceTypeUnknown},
+		{"po.backup", ResourceTypeUnknown},
+		{"sc", ResourceTypeStorageClass},
+		{"pc", ResourceTypePriorityClass},
+		{"volumeattachments", ResourceTypeVolumeAttachment},
+		{"ev", ResourceTypeEvent},
 	}
 
 	for _, v := range testDatas {
@@ -33,6 +50,13 @@ func TestGetResourceType(t *testing.T) {
 		{[]string{""}, ResourceTypeApiResource},
 		{[]string{"pods"}, ResourceTypeApiResource},
 		{[]string{"pods", ""}, ResourceTypePod},
+		{[]string{"deploy/"}, ResourceTypeDeployment},
+		{[]string{"pod/a", "pod/"}, ResourceTypePod},
+		{[]string{"pod/a", " "}, ResourceTypePod},
+		{[]string{"pods,svc", " "}, ResourceTypePod},
+		{[]string{"pods,"}, ResourceTypeApiResource},
+		{[]string{"-n", "default", "pods,"}, ResourceTypeApiResource},
+		{[]string{"unknown/a", " "}, ResourceTypeUnknown},
 	}
 	for _, testData := range testDatas {
 		parsedType := GetResourceType("get", testData.args)
@@ -61,3 +85,46 @@ func TestGetResourceSetFromSlice(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestParseTypeName(t *testing.T) {
+	testDatas := []struct {
+		arg          string
+		resourceType ResourceType
+		name         string
+		ok           bool
+	}{
+		{"deploy/coredns", ResourceTypeDeployment, "coredns", true},
+		{"pods/", ResourceTypePod, "", true},
+		{"deployments.apps/coredns", ResourceTypeDeployment, "coredns", true},
+		{"pods", ResourceTypeUnknown, "", false},
+		{"kube-system/coredns", ResourceTypeUnknown, "", false},
+		{"--selector=pod/a", ResourceTypeUnknown, "", false},
+	}
+	for _, testData := range testDatas {
+		resourceType, name, ok := ParseTypeName(testData.arg)
+		assert.Equal(t, testData.resourceType, resourceType, "Arg: %s", testData.arg)
+		assert.Equal(t, testData.name, name, "Arg: %s", testData.arg)
+		assert.Equal(t, testData.ok, ok, "Arg: %s", testData.arg)
+	}
+}
+
+func TestGetResourceTypes(t *testing.T) {
+	testDatas := []struct {
+		args          []string
+		resourceTypes []ResourceType
+	}{
+		{[]string{"pods"}, []ResourceType{ResourceTypePod}},
+		{[]string{"pods,svc"}, []ResourceType{ResourceTypePod, ResourceTypeService}},
+		{[]string{"pods,unknown"}, []ResourceType{}},
+		{[]string{"pod/a", "svc/b", "pod/c"}, []ResourceType{ResourceTypePod, ResourceTypeService}},
+		{[]string{"-n", "kube-system"}, []ResourceType{}},
+	}
+	for _, testData := range testDatas {
+		assert.Equal(t, testData.resourceTypes, GetResourceTypes(testData.args), "Args: %s", testData.args)
+	}
+	assert.True(t, IsResourceTypeListPrefix("pods,"))
+	assert.True(t, IsResourceTypeListPrefix("pods,svc,se"))
+	assert.False(t, IsResourceTypeListPrefix("pods"))
+	assert.False(t, IsResourceTypeListPrefix("app=a,"))
+	assert.False(t, IsResourceTypeListPrefix("-lpods,"))
+}
@@ -0,0 +1,45 @@
+package resources
+
+import (
+	"strconv"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// Role is the summary of a kubernetes role
+type Role struct {
+	ResourceMeta
+	NumberRules string
+}
+
+// NewRoleFromRuntime builds a role from informer result
+func NewRoleFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	r := &Role{}
+	r.FromRuntime(obj, config)
+	return r
+}
+
+// FromRuntime builds object from the informer's result
+func (r *Role) FromRuntime(obj interface{}, config CtorConfig) {
+	role := obj.(*rbacv1.Role)
+	r.FromObjectMeta(role.ObjectMeta, config)
+	r.NumberRules = strconv.Itoa(len(role.Rules))
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (r *Role) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (r *Role) ToStrings() []string {
+	lst := []string{
+		r.Namespace,
+		r.Name,
+		r.NumberRules,
+		r.resourceAge(),
+		r.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
@@ -0,0 +1,66 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// RoleBinding is the summary of a kubernetes role binding
+type RoleBinding struct {
+	ResourceMeta
+	Role     string
+	Subjects []string
+}
+
+// NewRoleBindingFromRuntime builds a role binding from informer result
+func NewRoleBindingFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	r := &RoleBinding{}
+	r.FromRuntime(obj, config)
+	return r
+}
+
+func roleRefToString(roleRef rbacv1.RoleRef) string {
+	return fmt.Sprintf("%s/%s", roleRef.Kind, roleRef.Name)
+}
+
+// subjectsToStrings formats subjects as kind/namespace/name, namespace is
+// only present for namespaced subjects like service accounts
+func subjectsToStrings(subjects []rbacv1.Subject) []string {
+	res := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		if subject.Namespace == "" {
+			res = append(res, fmt.Sprintf("%s/%s", subject.Kind, subject.Name))
+		} else {
+			res = append(res, fmt.Sprintf("%s/%s/%s", subject.Kind, subject.Namespace, subject.Name))
+		}
+	}
+	return res
+}
+
+// FromRuntime builds object from the informer's result
+func (r *RoleBinding) FromRuntime(obj interface{}, config CtorConfig) {
+	roleBinding := obj.(*rbacv1.RoleBinding)
+	r.FromObjectMeta(roleBinding.ObjectMeta, config)
+	r.Role = roleRefToString(roleBinding.RoleRef)
+	r.Subjects = subjectsToStrings(roleBinding.Subjects)
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (r *RoleBinding) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (r *RoleBinding) ToStrings() []string {
+	lst := []string{
+		r.Namespace,
+		r.Name,
+		r.Role,
+		util.JoinSlicesWithMaxOrNone(r.Subjects, 20, ","),
+		r.resourceAge(),
+		r.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
@@ -1,6 +1,7 @@
 package resources
 
 import (
+	"sort"
 	"strconv"
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
@@ -13,6 +14,7 @@ type Secret struct {
 	ResourceMeta
 	SecretType string
 	Data       string
+	Keys       []string // Only key names are stored, never the values
 }
 
 // NewSecretFromRuntime builds a secret from informer result
@@ -25,10 +27,23 @@ func NewSecretFromRuntime(obj interface{}, config CtorConfig) K8sResource {
 // FromRuntime builds object from the informer's result
 func (s *Secret) FromRuntime(obj interface{}, config CtorConfig) {
 	secret := obj.(*corev1.Secret)
-	logrus.Tracef("Reading meta %#v", secret)
+	logrus.Tracef("Reading meta of secret %s/%s", secret.Namespace, secret.Name)
 	s.FromObjectMeta(secret.ObjectMeta, config)
 	s.SecretType = string(secret.Type)
 	s.Data = strconv.Itoa(len(secret.Data))
+	s.Keys = nil
+	if !config.HideSecretKeys {
+		s.Keys = make([]string, 0, len(secret.Data))
+		for k := range secret.Data {
+			s.Keys = append(s.Keys, k)
+		}
+		sort.Strings(s.Keys)
+	}
+}
+
+// GetDataKeys returns the sorted key names of the secret
+func (s *Secret) GetDataKeys() []string {
+	return s.Keys
 }
 
 // HasChanged returns true if the resource's dump needs to be updated
@@ -43,6 +58,7 @@ func (s *Secret) ToStrings() []string {
 		s.Name,
 		s.SecretType,
 		s.Data,
+		util.TruncateString(util.JoinSlicesOrNone(s.Keys, ","), 300),
 		s.resourceAge(),
 		s.labelsString(),
 	}
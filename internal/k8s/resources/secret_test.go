@@ -0,0 +1,28 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSecretKeys(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db"},
+		Type:       corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"username": []byte("admin"),
+			"password": []byte("hunter2"),
+		},
+	}
+	s := NewSecretFromRuntime(secret, CtorConfig{}).(*Secret)
+	assert.Equal(t, []string{"password", "username"}, s.Keys)
+	assert.Contains(t, s.ToStrings()[0], "\tOpaque\t2\tpassword,username\t")
+	assert.NotContains(t, s.ToStrings()[0], "hunter2")
+
+	s = NewSecretFromRuntime(secret, CtorConfig{HideSecretKeys: true}).(*Secret)
+	assert.Nil(t, s.Keys)
+	assert.Contains(t, s.ToStrings()[0], "\tOpaque\t2\tNone\t")
+}
@@ -10,10 +10,11 @@ import (
 // Service is the summary of a kubernetes service
 type Service struct {
 	ResourceMeta
-	ServiceType string
-	ClusterIP   string
-	Ports       []string
-	Selectors   []string
+	ServiceType  string
+	ClusterIP    string
+	Ports        []string
+	Selectors    []string
+	PortMappings []PortInfo
 }
 
 // NewServiceFromRuntime builds a pod from informer result
@@ -30,7 +31,14 @@ func (s *Service) FromRuntime(obj interface{}, config CtorConfig) {
 	s.ServiceType = string(service.Spec.Type)
 	s.ClusterIP = service.Spec.ClusterIP
 	s.Ports = make([]string, len(service.Spec.Ports))
+	s.PortMappings = make([]PortInfo, len(service.Spec.Ports))
 	for k, v := range service.Spec.Ports {
+		s.PortMappings[k] = PortInfo{
+			Name:     v.Name,
+			Port:     v.Port,
+			Protocol: string(v.Protocol),
+			Target:   v.TargetPort.String(),
+		}
 		if v.NodePort > 0 {
 			s.Ports[k] = fmt.Sprintf("%s:%d/%d", v.Name, v.Port, v.NodePort)
 		} else {
@@ -43,9 +51,16 @@ func (s *Service) FromRuntime(obj interface{}, config CtorConfig) {
 // HasChanged returns true if the resource's dump needs to be updated
 func (s *Service) HasChanged(k K8sResource) bool {
 	oldService := k.(*Service)
-	return (util.StringSlicesEqual(s.Ports, oldService.Ports) ||
-		util.StringSlicesEqual(s.Selectors, oldService.Selectors) ||
-		util.StringMapsEqual(s.Labels, oldService.Labels))
+	return (!util.StringSlicesEqual(s.Ports, oldService.Ports) ||
+		!util.StringSlicesEqual(s.Selectors, oldService.Selectors) ||
+		!portInfosEqual(s.PortMappings, oldService.PortMappings) ||
+		!util.StringMapsEqual(s.Labels, oldService.Labels) ||
+		!util.StringMapsEqual(s.Annotations, oldService.Annotations))
+}
+
+// GetPorts returns the ports of the service with their target port
+func (s *Service) GetPorts() []PortInfo {
+	return s.PortMappings
 }
 
 // ToString serializes the object to strings
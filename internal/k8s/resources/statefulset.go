@@ -10,9 +10,29 @@ import (
 // StatefulSet is the summary of a kubernetes statefulset
 type StatefulSet struct {
 	ResourceMeta
-	currentReplicas int
-	replicas        int
-	selectors       []string
+	CurrentReplicas int
+	Replicas        int
+	Selectors       []string
+	WorkloadTemplate
+}
+
+func getStatefulSetRolloutStatus(statefulset *appsv1.StatefulSet) string {
+	status := statefulset.Status
+	if status.ObservedGeneration < statefulset.Generation {
+		return RolloutProgressing
+	}
+	desired := int32(1)
+	if statefulset.Spec.Replicas != nil {
+		desired = *statefulset.Spec.Replicas
+	}
+	if status.ReadyReplicas < desired {
+		return RolloutProgressing
+	}
+	if statefulset.Spec.UpdateStrategy.Type == appsv1.RollingUpdateStatefulSetStrategyType &&
+		status.UpdateRevision != status.CurrentRevision {
+		return RolloutProgressing
+	}
+	return RolloutComplete
 }
 
 // NewStatefulSetFromRuntime builds a k8sresource from informer result
@@ -26,30 +46,40 @@ func NewStatefulSetFromRuntime(obj interface{}, config CtorConfig) K8sResource {
 func (s *StatefulSet) FromRuntime(obj interface{}, config CtorConfig) {
 	statefulset := obj.(*appsv1.StatefulSet)
 	s.FromObjectMeta(statefulset.ObjectMeta, config)
-	s.currentReplicas = int(statefulset.Status.CurrentReplicas)
-	s.replicas = int(statefulset.Status.Replicas)
-	s.selectors = util.JoinStringMap(statefulset.Spec.Selector.MatchLabels, ExcludedLabels, "=")
+	s.CurrentReplicas = int(statefulset.Status.CurrentReplicas)
+	s.Replicas = int(statefulset.Status.Replicas)
+	s.Selectors = util.JoinStringMap(statefulset.Spec.Selector.MatchLabels, ExcludedLabels, "=")
+
+	s.fromPodTemplate(statefulset.Spec.Template)
+	s.setGeneration(statefulset.Status.ObservedGeneration, statefulset.Generation)
+	s.UpdateStrategy = string(statefulset.Spec.UpdateStrategy.Type)
+	s.RolloutStatus = getStatefulSetRolloutStatus(statefulset)
 }
 
 // HasChanged returns true if the resource's dump needs to be updated
 func (s *StatefulSet) HasChanged(k K8sResource) bool {
-	oldSts := k.(*StatefulSet)
-	return (s.currentReplicas != oldSts.currentReplicas ||
-		s.replicas != oldSts.replicas ||
-		util.StringSlicesEqual(s.selectors, oldSts.selectors) ||
-		util.StringMapsEqual(s.Labels, oldSts.Labels))
+	oldSts, ok := k.(*StatefulSet)
+	if !ok {
+		return true
+	}
+	return (s.CurrentReplicas != oldSts.CurrentReplicas ||
+		s.Replicas != oldSts.Replicas ||
+		!util.StringSlicesEqual(s.Selectors, oldSts.Selectors) ||
+		!util.StringMapsEqual(s.Labels, oldSts.Labels) ||
+		!util.StringMapsEqual(s.Annotations, oldSts.Annotations) ||
+		s.WorkloadTemplate.hasChanged(&oldSts.WorkloadTemplate))
 }
 
 // ToString serializes the object to strings
 func (s *StatefulSet) ToStrings() []string {
-	selectorList := util.JoinSlicesOrNone(s.selectors, ",")
+	selectorList := util.JoinSlicesOrNone(s.Selectors, ",")
 	line := []string{
 		s.Namespace,
 		s.Name,
-		fmt.Sprintf("%d/%d", s.currentReplicas, s.replicas),
+		fmt.Sprintf("%d/%d", s.CurrentReplicas, s.Replicas),
 		selectorList,
-		s.resourceAge(),
-		s.labelsString(),
 	}
+	line = append(line, s.WorkloadTemplate.toStrings()...)
+	line = append(line, s.resourceAge(), s.labelsString())
 	return util.DumpLines(line)
 }
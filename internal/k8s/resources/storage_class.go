@@ -0,0 +1,65 @@
+package resources
+
+import (
+	"strconv"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+// StorageClass is the summary of a kubernetes storage class
+type StorageClass struct {
+	ResourceMeta
+	Provisioner          string
+	ReclaimPolicy        string
+	VolumeBindingMode    string
+	AllowVolumeExpansion string
+	IsDefault            string
+}
+
+// NewStorageClassFromRuntime builds a storage class from informer result
+func NewStorageClassFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	s := &StorageClass{}
+	s.FromRuntime(obj, config)
+	return s
+}
+
+// FromRuntime builds object from the informer's result
+func (s *StorageClass) FromRuntime(obj interface{}, config CtorConfig) {
+	storageClass := obj.(*storagev1.StorageClass)
+	s.FromObjectMeta(storageClass.ObjectMeta, config)
+	s.Provisioner = storageClass.Provisioner
+	s.ReclaimPolicy = ""
+	if storageClass.ReclaimPolicy != nil {
+		s.ReclaimPolicy = string(*storageClass.ReclaimPolicy)
+	}
+	s.VolumeBindingMode = ""
+	if storageClass.VolumeBindingMode != nil {
+		s.VolumeBindingMode = string(*storageClass.VolumeBindingMode)
+	}
+	s.AllowVolumeExpansion = "false"
+	if storageClass.AllowVolumeExpansion != nil {
+		s.AllowVolumeExpansion = strconv.FormatBool(*storageClass.AllowVolumeExpansion)
+	}
+	s.IsDefault = strconv.FormatBool(storageClass.Annotations["storageclass.kubernetes.io/is-default-class"] == "true")
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (s *StorageClass) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (s *StorageClass) ToStrings() []string {
+	lst := []string{
+		s.Name,
+		s.Provisioner,
+		s.ReclaimPolicy,
+		s.VolumeBindingMode,
+		s.AllowVolumeExpansion,
+		s.IsDefault,
+		s.resourceAge(),
+		s.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
@@ -0,0 +1,56 @@
+package resources
+
+import (
+	"strconv"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	storagev1 "k8s.io/api/storage/v1"
+)
+
+// VolumeAttachment is the summary of a kubernetes volume attachment
+type VolumeAttachment struct {
+	ResourceMeta
+	Attacher         string
+	NodeName         string
+	PersistentVolume string
+	Attached         string
+}
+
+// NewVolumeAttachmentFromRuntime builds a volume attachment from informer result
+func NewVolumeAttachmentFromRuntime(obj interface{}, config CtorConfig) K8sResource {
+	v := &VolumeAttachment{}
+	v.FromRuntime(obj, config)
+	return v
+}
+
+// FromRuntime builds object from the informer's result
+func (v *VolumeAttachment) FromRuntime(obj interface{}, config CtorConfig) {
+	volumeAttachment := obj.(*storagev1.VolumeAttachment)
+	v.FromObjectMeta(volumeAttachment.ObjectMeta, config)
+	v.Attacher = volumeAttachment.Spec.Attacher
+	v.NodeName = volumeAttachment.Spec.NodeName
+	v.PersistentVolume = ""
+	if volumeAttachment.Spec.Source.PersistentVolumeName != nil {
+		v.PersistentVolume = *volumeAttachment.Spec.Source.PersistentVolumeName
+	}
+	v.Attached = strconv.FormatBool(volumeAttachment.Status.Attached)
+}
+
+// HasChanged returns true if the resource's dump needs to be updated
+func (v *VolumeAttachment) HasChanged(k K8sResource) bool {
+	return true
+}
+
+// ToStrings serializes the object to strings
+func (v *VolumeAttachment) ToStrings() []string {
+	lst := []string{
+		v.Name,
+		v.Attacher,
+		v.NodeName,
+		v.PersistentVolume,
+		v.Attached,
+		v.resourceAge(),
+		v.labelsString(),
+	}
+	return util.DumpLines(lst)
+}
@@ -0,0 +1,88 @@
+package resources
+
+import (
+	"fmt"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Rollout status derived from the workload's status
+const (
+	RolloutProgressing = "Progressing"
+	RolloutComplete    = "Complete"
+	RolloutStalled     = "Stalled"
+)
+
+// WorkloadTemplate is the summary of the pod template and rollout of a workload
+type WorkloadTemplate struct {
+	TemplateContainers []string
+	TemplateImages     []string
+	Generation         string // observed/current
+	UpdateStrategy     string
+	RolloutStatus      string
+	TemplatePorts      []PortInfo
+}
+
+// TemplateGetter is implemented by workloads managing pods from a template
+type TemplateGetter interface {
+	GetName() string
+	GetNamespace() string
+	GetTemplate() *WorkloadTemplate
+}
+
+// GetTemplate returns the pod template summary of the workload
+func (w *WorkloadTemplate) GetTemplate() *WorkloadTemplate {
+	return w
+}
+
+func (w *WorkloadTemplate) fromPodTemplate(template corev1.PodTemplateSpec) {
+	containers := template.Spec.Containers
+	w.TemplateContainers = make([]string, len(containers))
+	w.TemplateImages = make([]string, len(containers))
+	for k, v := range containers {
+		w.TemplateContainers[k] = v.Name
+		w.TemplateImages[k] = v.Image
+	}
+	w.TemplatePorts = getContainerPorts(containers)
+}
+
+// GetPorts returns the container ports of the pod template
+func (w *WorkloadTemplate) GetPorts() []PortInfo {
+	return w.TemplatePorts
+}
+
+func (w *WorkloadTemplate) setGeneration(observedGeneration int64, generation int64) {
+	w.Generation = fmt.Sprintf("%d/%d", observedGeneration, generation)
+}
+
+func (w *WorkloadTemplate) hasChanged(old *WorkloadTemplate) bool {
+	return w.Generation != old.Generation ||
+		w.RolloutStatus != old.RolloutStatus ||
+		w.UpdateStrategy != old.UpdateStrategy ||
+		!util.StringSlicesEqual(w.TemplateImages, old.TemplateImages) ||
+		!portInfosEqual(w.TemplatePorts, old.TemplatePorts)
+}
+
+func (w *WorkloadTemplate) toStrings() []string {
+	shortImages := make([]string, len(w.TemplateImages))
+	for k, v := range w.TemplateImages {
+		shortImages[k] = getShortImage(v)
+	}
+	return []string{
+		util.JoinSlicesOrNone(w.TemplateContainers, ","),
+		util.TruncateString(util.JoinSlicesOrNone(shortImages, ","), 300),
+		w.Generation,
+		w.UpdateStrategy,
+		w.RolloutStatus,
+	}
+}
+
+// ContainersToStrings serializes the template containers of the workload to strings
+func (w *WorkloadTemplate) ContainersToStrings(namespace string, name string) []string {
+	res := []string{}
+	for k, container := range w.TemplateContainers {
+		res = append(res, util.DumpLines([]string{namespace, name, container, w.TemplateImages[k]})...)
+	}
+	return res
+}
@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDeploymentRolloutStatus(t *testing.T) {
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web", Generation: 3},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+			Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{Containers: []corev1.Container{
+				{Name: "web", Image: "registry.example.com/web:v1.42"},
+				{Name: "proxy", Image: "envoy:v1.25"},
+			}}},
+		},
+		Status: appsv1.DeploymentStatus{
+			ObservedGeneration: 3,
+			Replicas:           2,
+			UpdatedReplicas:    2,
+			AvailableReplicas:  2,
+		},
+	}
+	d := NewDeploymentFromRuntime(deployment, CtorConfig{}).(*Deployment)
+	assert.Equal(t, []string{"web", "proxy"}, d.TemplateContainers)
+	assert.Equal(t, "3/3", d.Generation)
+	assert.Equal(t, "RollingUpdate", d.UpdateStrategy)
+	assert.Equal(t, RolloutComplete, d.RolloutStatus)
+	assert.Contains(t, d.ToStrings()[0], "\tweb,proxy\tweb:v1.42,envoy:v1.25\t3/3\tRollingUpdate\tComplete\t")
+
+	// New generation not observed yet
+	deployment.Generation = 4
+	d = NewDeploymentFromRuntime(deployment, CtorConfig{}).(*Deployment)
+	assert.Equal(t, RolloutProgressing, d.RolloutStatus)
+
+	deployment.Status.Conditions = []appsv1.DeploymentCondition{
+		{Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+	}
+	d = NewDeploymentFromRuntime(deployment, CtorConfig{}).(*Deployment)
+	assert.Equal(t, RolloutStalled, d.RolloutStatus)
+}
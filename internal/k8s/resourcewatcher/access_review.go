@@ -0,0 +1,262 @@
+package resourcewatcher
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	"github.com/sirupsen/logrus"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// watchVerbs are the verbs needed by the informers
+var watchVerbs = []string{"list", "watch"}
+
+// accessReviewTTL is how long access review results are reused by the next reviews
+const accessReviewTTL = 10 * time.Minute
+
+// AccessDecision is the result of the permission check done before starting a watcher
+type AccessDecision struct {
+	Allowed    bool
+	Namespaces []string // Namespaces allowed when the resource can't be watched cluster wide
+	Err        error    // Set when the access couldn't be reviewed
+}
+
+func (a AccessDecision) String() string {
+	if a.Err != nil {
+		return fmt.Sprintf("review failed: %s", a.Err)
+	}
+	if !a.Allowed {
+		return "forbidden"
+	}
+	if len(a.Namespaces) > 0 {
+		return fmt.Sprintf("restricted to %s", strings.Join(a.Namespaces, ","))
+	}
+	return "allowed"
+}
+
+func getApiGroup(cfg WatchConfig) string {
+	gvks, _, err := scheme.Scheme.ObjectKinds(cfg.runtimeObject)
+	if err != nil || len(gvks) == 0 {
+		logrus.Warnf("Couldn't find api group of %s: %v", cfg.resourceType, err)
+		return ""
+	}
+	return gvks[0].Group
+}
+
+func isAccessAllowed(ctx context.Context, clientset *kubernetes.Clientset,
+	namespace string, group string, resource string) (bool, error) {
+	for _, verb := range watchVerbs {
+		review := &authorizationv1.SelfSubjectAccessReview{
+			Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationv1.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      verb,
+					Group:     group,
+					Resource:  resource,
+				},
+			},
+		}
+		res, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return false, err
+		}
+		if !res.Status.Allowed {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+type accessReviewResult struct {
+	allowed bool
+	time    time.Time
+}
+
+// accessReviewCache keeps the access review results across reviews
+type accessReviewCache struct {
+	mutex   sync.Mutex
+	results map[string]accessReviewResult
+}
+
+func (a *accessReviewCache) isAccessAllowed(ctx context.Context, clientset *kubernetes.Clientset,
+	namespace string, group string, resource string) (bool, error) {
+	key := fmt.Sprintf("%s/%s/%s", namespace, group, resource)
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	result, ok := a.results[key]
+	if ok && time.Since(result.time) < accessReviewTTL {
+		return result.allowed, nil
+	}
+	allowed, err := isAccessAllowed(ctx, clientset, namespace, group, resource)
+	if err != nil {
+		return false, err
+	}
+	if a.results == nil {
+		a.results = make(map[string]accessReviewResult, 0)
+	}
+	a.results[key] = accessReviewResult{allowed, time.Now()}
+	return allowed, nil
+}
+
+func isRuleMatching(values []string, value string) bool {
+	return util.IsStringIn("*", values) || util.IsStringIn(value, values)
+}
+
+// rulesAllowWatch returns true if the rules allow list and watch on the whole resource
+func rulesAllowWatch(rules []authorizationv1.ResourceRule, group string, resource string) bool {
+	for _, verb := range watchVerbs {
+		verbAllowed := false
+		for _, rule := range rules {
+			if len(rule.ResourceNames) > 0 {
+				// Restricted to specific objects, not enough to list the resource
+				continue
+			}
+			if isRuleMatching(rule.Verbs, verb) &&
+				isRuleMatching(rule.APIGroups, group) &&
+				isRuleMatching(rule.Resources, resource) {
+				verbAllowed = true
+				break
+			}
+		}
+		if !verbAllowed {
+			return false
+		}
+	}
+	return true
+}
+
+// getCandidateNamespaces returns the namespaces to check when a resource
+// can't be watched cluster wide
+func (r *ResourceWatcher) getCandidateNamespaces(ctx context.Context, clientset *kubernetes.Clientset) []string {
+	if len(r.namespaces) > 0 {
+		return r.namespaces
+	}
+	candidates := []string{}
+	namespaces, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+	if err == nil {
+		for _, namespace := range namespaces.Items {
+			candidates = append(candidates, namespace.GetName())
+		}
+	} else {
+		logrus.Infof("Couldn't list namespaces, falling back to watched namespaces and context namespace: %s", err)
+		for _, watchNamespace := range r.watchNamespaces {
+			candidates = append(candidates, watchNamespace.String())
+		}
+		contextNamespace, err := r.storeConfig.GetNamespace()
+		if err == nil {
+			if contextNamespace == "" {
+				contextNamespace = "default"
+			}
+			if !util.IsStringIn(contextNamespace, candidates) {
+				candidates = append(candidates, contextNamespace)
+			}
+		}
+	}
+	res := []string{}
+	for _, namespace := range candidates {
+		if r.isNamespaceWatched(namespace) {
+			res = append(res, namespace)
+		}
+	}
+	return res
+}
+
+type namespaceRulesCache struct {
+	rules   map[string][]authorizationv1.ResourceRule
+	reviews *accessReviewCache
+}
+
+func (n namespaceRulesCache) isAllowed(ctx context.Context, clientset *kubernetes.Clientset,
+	namespace string, group string, resource string) (bool, error) {
+	rules, ok := n.rules[namespace]
+	if !ok {
+		review := &authorizationv1.SelfSubjectRulesReview{
+			Spec: authorizationv1.SelfSubjectRulesReviewSpec{Namespace: namespace},
+		}
+		res, err := clientset.AuthorizationV1().SelfSubjectRulesReviews().Create(ctx, review, metav1.CreateOptions{})
+		if err != nil {
+			return false, err
+		}
+		if res.Status.Incomplete {
+			// Some authorizers can't enumerate rules, ask directly
+			return n.reviews.isAccessAllowed(ctx, clientset, namespace, group, resource)
+		}
+		rules = res.Status.ResourceRules
+		n.rules[namespace] = rules
+	}
+	return rulesAllowWatch(rules, group, resource), nil
+}
+
+// ReviewAccess checks list and watch permissions of the watch configs.
+// Resources forbidden cluster wide are restricted to the namespaces where
+// they are allowed, or skipped if no namespaces are allowed.
+func (r *ResourceWatcher) ReviewAccess(ctx context.Context, watchConfigs []WatchConfig) error {
+	r.accessDecisions = make(map[resources.ResourceType]AccessDecision, 0)
+	if !r.accessReview {
+		return nil
+	}
+	clientset, err := r.storeConfig.GetClientset()
+	if err != nil {
+		return err
+	}
+	var candidateNamespaces []string
+	rulesCache := namespaceRulesCache{
+		rules:   make(map[string][]authorizationv1.ResourceRule, 0),
+		reviews: r.accessReviews,
+	}
+	for _, cfg := range watchConfigs {
+		resourceType := cfg.resourceType
+		group := getApiGroup(cfg)
+		resource := cfg.getResourceName()
+		allowed, err := r.accessReviews.isAccessAllowed(ctx, clientset, "", group, resource)
+		if err != nil {
+			// Not watched until the next review succeeds
+			logrus.Warnf("Couldn't review access of %s, skipping it: %s", resourceType, err)
+			r.accessDecisions[resourceType] = AccessDecision{Err: err}
+			continue
+		}
+		if allowed {
+			r.accessDecisions[resourceType] = AccessDecision{Allowed: true}
+			continue
+		}
+		decision := AccessDecision{}
+		if resourceType.IsNamespaced() {
+			if candidateNamespaces == nil {
+				candidateNamespaces = r.getCandidateNamespaces(ctx, clientset)
+			}
+			for _, namespace := range candidateNamespaces {
+				allowed, err := rulesCache.isAllowed(ctx, clientset, namespace, group, resource)
+				if err != nil {
+					logrus.Warnf("Couldn't review access of %s in namespace %s: %s", resourceType, namespace, err)
+					continue
+				}
+				if allowed {
+					decision.Namespaces = append(decision.Namespaces, namespace)
+				}
+			}
+			decision.Allowed = len(decision.Namespaces) > 0
+		}
+		r.accessDecisions[resourceType] = decision
+	}
+	for _, cfg := range watchConfigs {
+		logrus.Infof("Access review of %s: %s", cfg.resourceType, r.getAccessDecision(cfg.resourceType))
+	}
+	return nil
+}
+
+func (r *ResourceWatcher) getAccessDecision(resourceType resources.ResourceType) AccessDecision {
+	decision, ok := r.accessDecisions[resourceType]
+	if !ok {
+		// Not reviewed, let the informer's error handler deal with permissions
+		return AccessDecision{Allowed: true}
+	}
+	return decision
+}
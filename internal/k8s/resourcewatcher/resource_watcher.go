@@ -2,7 +2,9 @@ package resourcewatcher
 
 import (
 	"context"
+	"fmt"
 	"regexp"
+	"sync"
 	"time"
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
@@ -14,7 +16,12 @@ import (
 	autoscalingv1 "k8s.io/api/autoscaling/v1"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -28,10 +35,20 @@ import (
 
 // ResourceWatcher contains rest clients for a given kubernetes context
 type ResourceWatcher struct {
-	namespaces  []string // List of namespaces filtered using excludedNamespaces
-	cancelFuncs []context.CancelFunc
-	storeConfig *store.StoreConfig
+	watcherConfig
+	storeConfig   *store.StoreConfig
+	accessReviews *accessReviewCache // Shared by the reviews of each reconcile
 
+	watchesMutex sync.Mutex
+	watches      map[resources.ResourceType]*runningWatch
+}
+
+// watcherConfig is the configuration applied to the watchers,
+// replaced as a whole on reconcile
+type watcherConfig struct {
+	namespaces []string // List of namespaces filtered using excludedNamespaces
+
+	resourceWatcherCli     ResourceWatcherCli
 	watchResourcesSet      map[resources.ResourceType]bool
 	excludeResourcesSet    map[resources.ResourceType]bool
 	excludeNamespaces      []*regexp.Regexp
@@ -40,6 +57,9 @@ type ResourceWatcher struct {
 	nodePollingPeriod      time.Duration
 	ctorConfig             resources.CtorConfig
 	exitOnUnauthorized     bool
+	accessReview           bool
+	legacyEndpoints        bool
+	accessDecisions        map[resources.ResourceType]AccessDecision
 }
 
 // WatchConfig provides the configuration to watch a specific kubernetes resource
@@ -51,59 +71,241 @@ type WatchConfig struct {
 	pollingPeriod time.Duration
 }
 
+// getResourceName returns the name of the watched k8s resource
+func (w WatchConfig) getResourceName() string {
+	if _, ok := w.runtimeObject.(*discoveryv1.EndpointSlice); ok {
+		return "endpointslices"
+	}
+	return w.resourceType.String()
+}
+
+// runningWatch keeps track of a started watch/poll and its store
+type runningWatch struct {
+	cfg    WatchConfig
+	store  *store.Store
+	cancel context.CancelFunc
+}
+
 // NewResourceWatcher creates a new resource watcher on a given cluster
 func NewResourceWatcher(cluster string, resourceWatcherCli ResourceWatcherCli, storeConfig *store.StoreConfig) (*ResourceWatcher, error) {
-	excludedNamespaces, err := util.StringSliceToRegexps(resourceWatcherCli.excludNamespaces)
+	resourceWatcher := ResourceWatcher{
+		storeConfig:   storeConfig,
+		accessReviews: &accessReviewCache{},
+		watches:       make(map[resources.ResourceType]*runningWatch, 0),
+	}
+	err := resourceWatcher.setConfig(resourceWatcherCli)
 	if err != nil {
 		return nil, err
 	}
-	watchedNamespaces, err := util.StringSliceToRegexps(resourceWatcherCli.watchNamespaces)
+	return &resourceWatcher, nil
+}
+
+// setConfig validates and applies the watcher configuration
+func (r *ResourceWatcher) setConfig(resourceWatcherCli ResourceWatcherCli) error {
+	excludedNamespaces, err := util.StringSliceToRegexps(resourceWatcherCli.ExcludeNamespaces)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	ignoredNodeRoles := util.StringSliceToSet(resourceWatcherCli.ignoreNodeRoles)
-	excludedResources, err := resources.GetResourceSetFromSlice(resourceWatcherCli.excludResources)
+	watchedNamespaces, err := util.StringSliceToRegexps(resourceWatcherCli.WatchNamespaces)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	watchedResources, err := resources.GetResourceSetFromSlice(resourceWatcherCli.watchResources)
+	ignoredNodeRoles := util.StringSliceToSet(resourceWatcherCli.IgnoreNodeRoles)
+	excludedResources, err := resources.GetResourceSetFromSlice(resourceWatcherCli.ExcludeResources)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	resourceWatcher := ResourceWatcher{
-		storeConfig:            storeConfig,
-		excludeResourcesSet:    excludedResources,
-		watchResourcesSet:      watchedResources,
-		excludeNamespaces:      excludedNamespaces,
-		watchNamespaces:        watchedNamespaces,
-		nodePollingPeriod:      resourceWatcherCli.nodePollingPeriod,
-		namespacePollingPeriod: resourceWatcherCli.namespacePollingPeriod,
-		ctorConfig: resources.CtorConfig{
-			IgnoredNodeRoles: ignoredNodeRoles,
-		},
-		exitOnUnauthorized: resourceWatcherCli.exitOnUnauthorized,
+	watchedResources, err := resources.GetResourceSetFromSlice(resourceWatcherCli.WatchResources)
+	if err != nil {
+		return err
 	}
-	return &resourceWatcher, nil
+	r.resourceWatcherCli = resourceWatcherCli
+	r.excludeResourcesSet = excludedResources
+	r.watchResourcesSet = watchedResources
+	r.excludeNamespaces = excludedNamespaces
+	r.watchNamespaces = watchedNamespaces
+	r.nodePollingPeriod = resourceWatcherCli.NodePollingPeriod
+	r.namespacePollingPeriod = resourceWatcherCli.NamespacePollingPeriod
+	r.ctorConfig = resources.CtorConfig{
+		IgnoredNodeRoles:    ignoredNodeRoles,
+		HideSecretKeys:      resourceWatcherCli.HideSecretKeys,
+		ExcludedAnnotations: util.StringSliceToSet(resourceWatcherCli.ExcludeAnnotations),
+	}
+	r.exitOnUnauthorized = resourceWatcherCli.ExitOnUnauthorized
+	r.accessReview = resourceWatcherCli.AccessReview
+	r.legacyEndpoints = resourceWatcherCli.LegacyEndpoints
+	return nil
+}
+
+// GetResourceWatcherCli returns the configuration currently applied
+func (r *ResourceWatcher) GetResourceWatcherCli() ResourceWatcherCli {
+	r.watchesMutex.Lock()
+	defer r.watchesMutex.Unlock()
+	return r.resourceWatcherCli
 }
 
 // Start begins the watch/poll of a given k8s resource
 func (r *ResourceWatcher) Start(parentCtx context.Context, cfg WatchConfig) *store.Store {
+	r.watchesMutex.Lock()
+	defer r.watchesMutex.Unlock()
+	return r.startLocked(parentCtx, cfg)
+}
+
+func (r *ResourceWatcher) startLocked(parentCtx context.Context, cfg WatchConfig) *store.Store {
 	ctx, cancel := context.WithCancel(parentCtx)
-	r.cancelFuncs = append(r.cancelFuncs, cancel)
 	store := store.NewStore(ctx, r.storeConfig, r.ctorConfig, cfg.resourceType)
+	r.watches[cfg.resourceType] = &runningWatch{cfg, store, cancel}
+	decision := r.getAccessDecision(cfg.resourceType)
+	store.SetAccess(decision.String())
+	if !decision.Allowed {
+		logrus.Warnf("Resource %s is not allowed (%s), skipping watcher", cfg.resourceType, decision)
+		store.SetSyncPending(0)
+		return store
+	}
+	namespaces := r.namespaces
+	if len(decision.Namespaces) > 0 {
+		namespaces = decision.Namespaces
+	}
+	if cfg.pollingPeriod == 0 && cfg.resourceType.IsNamespaced() && len(namespaces) > 0 {
+		store.SetSyncPending(len(namespaces))
+	}
 	if cfg.pollingPeriod > 0 {
 		go r.pollResource(ctx, cfg, store)
 	} else {
-		go r.watchResource(ctx, cfg, store, r.namespaces)
+		go r.watchResource(ctx, cfg, store, namespaces)
 	}
 	return store
 }
 
+func (r *ResourceWatcher) stopLocked(resourceType resources.ResourceType) {
+	w, ok := r.watches[resourceType]
+	if !ok {
+		return
+	}
+	w.cancel()
+	w.store.Stop()
+	delete(r.watches, resourceType)
+}
+
 // Stop closes the watch/poll process of a k8s resource
 func (r *ResourceWatcher) Stop() {
-	logrus.Infof("Stopping %d resource watcher", len(r.cancelFuncs))
-	for _, cancel := range r.cancelFuncs {
-		cancel()
+	r.watchesMutex.Lock()
+	defer r.watchesMutex.Unlock()
+	logrus.Infof("Stopping %d resource watcher", len(r.watches))
+	for resourceType := range r.watches {
+		r.stopLocked(resourceType)
+	}
+}
+
+// GetStores returns the stores of the running watchers
+func (r *ResourceWatcher) GetStores() []*store.Store {
+	r.watchesMutex.Lock()
+	defer r.watchesMutex.Unlock()
+	stores := make([]*store.Store, 0)
+	for resourceType := resources.ResourceTypeApiResource; resourceType < resources.ResourceTypeUnknown; resourceType++ {
+		if w, ok := r.watches[resourceType]; ok {
+			stores = append(stores, w.store)
+		}
+	}
+	return stores
+}
+
+// Resync restarts the watch/poll of a resource with a fresh store.
+// The previous dump is kept until the new store writes its own.
+func (r *ResourceWatcher) Resync(ctx context.Context, resourceType resources.ResourceType) error {
+	r.watchesMutex.Lock()
+	defer r.watchesMutex.Unlock()
+	w, ok := r.watches[resourceType]
+	if !ok {
+		return fmt.Errorf("resource %s is not watched", resourceType)
+	}
+	logrus.Infof("Resyncing %s", resourceType)
+	r.stopLocked(resourceType)
+	r.startLocked(ctx, w.cfg)
+	return nil
+}
+
+// Dump forces a full dump of a resource store
+func (r *ResourceWatcher) Dump(resourceType resources.ResourceType) error {
+	r.watchesMutex.Lock()
+	w, ok := r.watches[resourceType]
+	r.watchesMutex.Unlock()
+	if !ok {
+		return fmt.Errorf("resource %s is not watched", resourceType)
+	}
+	return w.store.ForceDumpFullState()
+}
+
+// ReconcilePlan is a new configuration validated for a running watcher
+type ReconcilePlan struct {
+	config       watcherConfig
+	watchConfigs []WatchConfig
+}
+
+// PrepareReconcile validates the new configuration, fetches its namespaces
+// and reviews its access. The running watchers are left untouched.
+func (r *ResourceWatcher) PrepareReconcile(ctx context.Context, resourceWatcherCli ResourceWatcherCli) (*ReconcilePlan, error) {
+	next := &ResourceWatcher{storeConfig: r.storeConfig, accessReviews: r.accessReviews}
+	err := next.setConfig(resourceWatcherCli)
+	if err != nil {
+		return nil, err
+	}
+	err = next.FetchNamespaces(ctx)
+	if err != nil {
+		return nil, err
+	}
+	watchConfigs, err := next.GetWatchConfigs()
+	if err != nil {
+		return nil, err
+	}
+	err = next.ReviewAccess(ctx, watchConfigs)
+	if err != nil {
+		return nil, err
+	}
+	return &ReconcilePlan{config: next.watcherConfig, watchConfigs: watchConfigs}, nil
+}
+
+// ApplyReconcile applies a prepared configuration to the running watcher.
+// Watchers of removed resources are stopped, new resources are started
+// and watchers whose namespaces or polling period changed are restarted.
+func (r *ResourceWatcher) ApplyReconcile(ctx context.Context, plan *ReconcilePlan) {
+	r.watchesMutex.Lock()
+	defer r.watchesMutex.Unlock()
+	previousCli := r.resourceWatcherCli
+	previousNamespaces := r.namespaces
+	r.watcherConfig = plan.config
+	resourceWatcherCli := r.resourceWatcherCli
+	namespacesChanged := !util.StringSlicesEqual(previousNamespaces, r.namespaces) ||
+		!util.StringSlicesEqual(previousCli.WatchNamespaces, resourceWatcherCli.WatchNamespaces) ||
+		!util.StringSlicesEqual(previousCli.ExcludeNamespaces, resourceWatcherCli.ExcludeNamespaces)
+	ctorConfigChanged := !util.StringSlicesEqual(previousCli.IgnoreNodeRoles, resourceWatcherCli.IgnoreNodeRoles) ||
+		previousCli.HideSecretKeys != resourceWatcherCli.HideSecretKeys ||
+		!util.StringSlicesEqual(previousCli.ExcludeAnnotations, resourceWatcherCli.ExcludeAnnotations)
+
+	wantedTypes := make(map[resources.ResourceType]bool, 0)
+	for _, cfg := range plan.watchConfigs {
+		wantedTypes[cfg.resourceType] = true
+		w, ok := r.watches[cfg.resourceType]
+		if !ok {
+			logrus.Infof("Starting watcher of %s", cfg.resourceType)
+			r.startLocked(ctx, cfg)
+			continue
+		}
+		restart := ctorConfigChanged ||
+			w.cfg.pollingPeriod != cfg.pollingPeriod ||
+			w.cfg.getResourceName() != cfg.getResourceName() ||
+			(namespacesChanged && cfg.resourceType.IsNamespaced())
+		if restart {
+			logrus.Infof("Restarting watcher of %s", cfg.resourceType)
+			r.stopLocked(cfg.resourceType)
+			r.startLocked(ctx, cfg)
+		}
+	}
+	for resourceType := range r.watches {
+		if !wantedTypes[resourceType] {
+			logrus.Infof("Stopping watcher of %s", resourceType)
+			r.stopLocked(resourceType)
+		}
 	}
 }
 
@@ -118,6 +320,14 @@ func (r *ResourceWatcher) GetWatchConfigs() ([]WatchConfig, error) {
 	autoscalingGetter := clientset.AutoscalingV1().RESTClient()
 	networkingGetter := clientset.NetworkingV1().RESTClient()
 	batchGetter := clientset.BatchV1().RESTClient()
+	rbacGetter := clientset.RbacV1().RESTClient()
+	policyGetter := clientset.PolicyV1().RESTClient()
+	storageGetter := clientset.StorageV1().RESTClient()
+	schedulingGetter := clientset.SchedulingV1().RESTClient()
+	endpointsConfig := WatchConfig{resources.ResourceTypeEndpoints, clientset.DiscoveryV1().RESTClient(), &discoveryv1.EndpointSlice{}, true, 0}
+	if r.legacyEndpoints {
+		endpointsConfig = WatchConfig{resources.ResourceTypeEndpoints, coreGetter, &corev1.Endpoints{}, true, 0}
+	}
 	allWatchConfigs := []WatchConfig{
 		{resources.ResourceTypePod, coreGetter, &corev1.Pod{}, true, 0},
 		{resources.ResourceTypeConfigMap, coreGetter, &corev1.ConfigMap{}, true, 0},
@@ -128,7 +338,7 @@ func (r *ResourceWatcher) GetWatchConfigs() ([]WatchConfig, error) {
 		{resources.ResourceTypeSecret, coreGetter, &corev1.Secret{}, true, 0},
 		{resources.ResourceTypeStatefulSet, appsGetter, &appsv1.StatefulSet{}, true, 0},
 		{resources.ResourceTypeDeployment, appsGetter, &appsv1.Deployment{}, true, 0},
-		{resources.ResourceTypeEndpoints, coreGetter, &corev1.Endpoints{}, true, 0},
+		endpointsConfig,
 		{resources.ResourceTypeIngress, networkingGetter, &networkingv1.Ingress{}, true, 0},
 		{resources.ResourceTypeCronJob, batchGetter, &batchv1.CronJob{}, true, 0},
 		{resources.ResourceTypeJob, batchGetter, &batchv1.Job{}, true, 0},
@@ -137,6 +347,20 @@ func (r *ResourceWatcher) GetWatchConfigs() ([]WatchConfig, error) {
 		{resources.ResourceTypePersistentVolumeClaim, coreGetter, &corev1.PersistentVolumeClaim{}, true, 0},
 		{resources.ResourceTypeNode, coreGetter, &corev1.Node{}, false, r.nodePollingPeriod},
 		{resources.ResourceTypeNamespace, coreGetter, &corev1.Namespace{}, false, r.namespacePollingPeriod},
+		{resources.ResourceTypeRole, rbacGetter, &rbacv1.Role{}, true, 0},
+		{resources.ResourceTypeRoleBinding, rbacGetter, &rbacv1.RoleBinding{}, true, 0},
+		{resources.ResourceTypeClusterRole, rbacGetter, &rbacv1.ClusterRole{}, false, 0},
+		{resources.ResourceTypeClusterRoleBinding, rbacGetter, &rbacv1.ClusterRoleBinding{}, false, 0},
+		{resources.ResourceTypeNetworkPolicy, networkingGetter, &networkingv1.NetworkPolicy{}, true, 0},
+		{resources.ResourceTypePodDisruptionBudget, policyGetter, &policyv1.PodDisruptionBudget{}, true, 0},
+		{resources.ResourceTypeResourceQuota, coreGetter, &corev1.ResourceQuota{}, true, 0},
+		{resources.ResourceTypeLimitRange, coreGetter, &corev1.LimitRange{}, true, 0},
+		{resources.ResourceTypeStorageClass, storageGetter, &storagev1.StorageClass{}, false, 0},
+		{resources.ResourceTypeIngressClass, networkingGetter, &networkingv1.IngressClass{}, false, 0},
+		{resources.ResourceTypePriorityClass, schedulingGetter, &schedulingv1.PriorityClass{}, false, 0},
+		{resources.ResourceTypeCSIDriver, storageGetter, &storagev1.CSIDriver{}, false, 0},
+		{resources.ResourceTypeVolumeAttachment, storageGetter, &storagev1.VolumeAttachment{}, false, 0},
+		{resources.ResourceTypeEvent, coreGetter, &corev1.Event{}, true, 0},
 	}
 	watchConfigs := []WatchConfig{}
 	for _, w := range allWatchConfigs {
@@ -168,7 +392,7 @@ func (r *ResourceWatcher) doPoll(cacheListWatch *cache.ListWatch, store *store.S
 // the resource watcher with an initial list of namespaces
 // This is only useful when we need to filter namespaces
 func (r *ResourceWatcher) FetchNamespaces(ctx context.Context) error {
-	if len(r.watchNamespaces) == 0 || len(r.excludeNamespaces) == 0 {
+	if len(r.watchNamespaces) == 0 && len(r.excludeNamespaces) == 0 {
 		// No need for namespace filtering
 		return nil
 	}
@@ -187,20 +411,27 @@ func (r *ResourceWatcher) FetchNamespaces(ctx context.Context) error {
 	}
 	for _, namespace := range namespaces.Items {
 		namespaceName := namespace.GetName()
-		if util.IsStringMatching(namespaceName, r.excludeNamespaces) {
-			logrus.Infof("namespace %s is in excluded namespaces, excluding", namespaceName)
-			continue
-		}
-		if len(r.watchNamespaces) > 0 && !util.IsStringMatching(namespaceName, r.watchNamespaces) {
-			logrus.Infof("namespace %s not in watched namespace, excluding", namespaceName)
-			continue
+		if r.isNamespaceWatched(namespaceName) {
+			r.namespaces = append(r.namespaces, namespaceName)
 		}
-		r.namespaces = append(r.namespaces, namespaceName)
 	}
 	logrus.Infof("Fetched %d namespaces", len(r.namespaces))
 	return nil
 }
 
+// isNamespaceWatched applies the namespace include and exclude filters
+func (r *ResourceWatcher) isNamespaceWatched(namespaceName string) bool {
+	if util.IsStringMatching(namespaceName, r.excludeNamespaces) {
+		logrus.Infof("namespace %s is in excluded namespaces, excluding", namespaceName)
+		return false
+	}
+	if len(r.watchNamespaces) > 0 && !util.IsStringMatching(namespaceName, r.watchNamespaces) {
+		logrus.Infof("namespace %s not in watched namespace, excluding", namespaceName)
+		return false
+	}
+	return true
+}
+
 // DumpAPIResources dumps api resources file
 func (r *ResourceWatcher) DumpAPIResources() error {
 	destFile := r.storeConfig.GetResourceStorePath(resources.ResourceTypeApiResource)
@@ -212,14 +443,24 @@ func (r *ResourceWatcher) DumpAPIResources() error {
 	if err != nil {
 		return err
 	}
+	// Preferred resources don't include the subresources like deployments/scale
+	_, allResourceLists, err := clientset.Discovery().ServerGroupsAndResources()
+	if err != nil {
+		logrus.Infof("Error listing api subresources: %v", err)
+	}
 	res := map[string]resources.K8sResource{}
 	for _, resourceList := range resourceLists {
 		a := resources.APIResourceList{}
 		a.FromRuntime(resourceList, r.ctorConfig)
+		a.SetSubresources(allResourceLists)
 		res[resourceList.GroupVersion] = &a
 	}
 	err = util.EncodeToFile(res, destFile)
-	return err
+	if err != nil {
+		return err
+	}
+	r.storeConfig.WriteSharedState(resources.ResourceTypeApiResource, res)
+	return nil
 }
 
 func (r *ResourceWatcher) getCacheListWatch(cfg WatchConfig, store *store.Store, namespace string) *cache.ListWatch {
@@ -228,7 +469,7 @@ func (r *ResourceWatcher) getCacheListWatch(cfg WatchConfig, store *store.Store,
 		options.ResourceVersion = "0"
 	}
 	cacheListWatch := cache.NewFilteredListWatchFromClient(cfg.getter,
-		cfg.resourceType.String(), namespace, optionsModifier)
+		cfg.getResourceName(), namespace, optionsModifier)
 	return cacheListWatch
 }
 
@@ -237,6 +478,7 @@ func (r *ResourceWatcher) pollResource(ctx context.Context,
 	logrus.Infof("Start poller for %s", cfg.resourceType)
 	cacheListWatch := r.getCacheListWatch(cfg, store, "")
 	r.doPoll(cacheListWatch, store)
+	store.MarkSynced()
 	ticker := time.NewTicker(cfg.pollingPeriod)
 	for {
 		select {
@@ -249,8 +491,21 @@ func (r *ResourceWatcher) pollResource(ctx context.Context,
 	}
 }
 
+// watchStop is the stop channel shared by the informers of a watch.
+// It is closed either on a forbidden error or when the watch is cancelled.
+type watchStop struct {
+	ch   chan struct{}
+	once sync.Once
+}
+
+func (s *watchStop) close() {
+	s.once.Do(func() {
+		close(s.ch)
+	})
+}
+
 func (r *ResourceWatcher) startWatch(cfg WatchConfig,
-	store *store.Store, namespace string, stop chan struct{}) {
+	store *store.Store, namespace string, stop *watchStop) {
 	cacheListWatch := r.getCacheListWatch(cfg, store, namespace)
 	resourceHandlers := cache.ResourceEventHandlerFuncs{
 		AddFunc:    store.AddResource,
@@ -264,23 +519,36 @@ func (r *ResourceWatcher) startWatch(cfg WatchConfig,
 		time.Second*0,
 	)
 	controller.AddEventHandler(resourceHandlers)
+	var syncOnce sync.Once
+	markSynced := func() {
+		syncOnce.Do(store.MarkSynced)
+	}
+	go func() {
+		if cache.WaitForCacheSync(stop.ch, controller.HasSynced) {
+			markSynced()
+		}
+	}()
 	watchErrorHandler := func(reflector *cache.Reflector, err error) {
 		if errors.IsUnauthorized(err) && r.exitOnUnauthorized {
 			logrus.Warnf("Resource %s is unauthorized, stopping watcher", cfg.resourceType)
-			r.Stop()
+			// Stop waits for the watches mutex, don't block the informer
+			go r.Stop()
 		}
 		if errors.IsForbidden(err) {
 			logrus.Warnf("Resource %s is forbidden, stopping watcher. err: %s", cfg.resourceType, err)
-			close(stop)
+			// Don't block readiness on a resource we can't list,
+			// informers of other namespaces are stopped too
+			store.SetSyncPending(0)
+			stop.close()
 		}
 	}
 	controller.SetWatchErrorHandler(watchErrorHandler)
-	controller.Run(stop)
+	controller.Run(stop.ch)
 }
 
 func (r *ResourceWatcher) watchResource(ctx context.Context,
 	cfg WatchConfig, store *store.Store, namespaces []string) {
-	stop := make(chan struct{})
+	stop := &watchStop{ch: make(chan struct{})}
 	resourceType := cfg.resourceType
 	isNamespaced := resourceType.IsNamespaced()
 	if !isNamespaced {
@@ -297,5 +565,5 @@ func (r *ResourceWatcher) watchResource(ctx context.Context,
 	}
 	<-ctx.Done()
 	logrus.Infof("Exiting watch of %s namespace %s", resourceType, namespaces)
-	close(stop)
+	stop.close()
 }
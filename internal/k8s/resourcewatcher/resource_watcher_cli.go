@@ -8,35 +8,48 @@ import (
 )
 
 type ResourceWatcherCli struct {
-	watchResources         []string
-	excludResources        []string
-	watchNamespaces        []string
-	excludNamespaces       []string
-	ignoreNodeRoles        []string
-	nodePollingPeriod      time.Duration
-	namespacePollingPeriod time.Duration
-	exitOnUnauthorized     bool
+	WatchResources         []string
+	ExcludeResources       []string
+	WatchNamespaces        []string
+	ExcludeNamespaces      []string
+	IgnoreNodeRoles        []string
+	NodePollingPeriod      time.Duration
+	NamespacePollingPeriod time.Duration
+	ExitOnUnauthorized     bool
+	AccessReview           bool
+	LegacyEndpoints        bool
+	HideSecretKeys         bool
+	ExcludeAnnotations     []string
 }
 
 func SetResourceWatcherCli(fs *pflag.FlagSet) {
 	fs.StringSlice("watch-resources", []string{}, "Resources to watch, separated by comma.")
-	fs.StringSlice("exclude-resources", []string{}, "Resources to exclude, separated by comma. To exclude everything: pods,configmaps,services,serviceaccounts,replicasets,daemonsets,secrets,statefulsets,deployments,endpoints,ingresses,cronjobs,jobs,horizontalpodautoscalers,persistentvolumes,persistentvolumeclaims,nodes,namespaces.")
+	fs.StringSlice("exclude-resources", []string{}, "Resources to exclude, separated by comma. To exclude everything: pods,configmaps,services,serviceaccounts,replicasets,daemonsets,secrets,statefulsets,deployments,endpoints,ingresses,cronjobs,jobs,horizontalpodautoscalers,persistentvolumes,persistentvolumeclaims,nodes,namespaces,roles,rolebindings,clusterroles,clusterrolebindings,networkpolicies,poddisruptionbudgets,resourcequotas,limitranges,storageclasses,ingressclasses,priorityclasses,csidrivers,volumeattachments,events.")
 	fs.StringSlice("watch-namespaces", []string{}, "Namespace regexps to watch, separated by comma.")
 	fs.StringSlice("exclude-namespaces", []string{}, "Namespace regexps to exclude, separated by comma.")
 	fs.StringSlice("ignore-node-roles", []string{}, "List of node role to ommit in the dump. It won't appaear in the completion. Useful to save space and remove cluster for 'common' node role. Separated by comma.")
 	fs.Duration("node-polling-period", 300*time.Second, "Polling period for nodes.")
 	fs.Duration("namespace-polling-period", 600*time.Second, "Polling period for namespaces.")
 	fs.Bool("exit-on-unauthorized", false, "Exit on unauthorized error.")
+	fs.Bool("legacy-endpoints", false, "Watch core endpoints instead of endpoint slices. Needed on clusters without discovery.k8s.io/v1.")
+	fs.StringSlice("exclude-annotations", []string{"kubectl.kubernetes.io/last-applied-configuration"}, "Annotation keys to omit in the dump, separated by comma.")
+	fs.Bool("hide-secret-keys", false, "Don't store the key names of secrets. They won't appear in the completion.")
+	fs.Bool("access-review", true, "Check list and watch permissions before starting watchers. Forbidden resources are skipped or restricted to the allowed namespaces.")
 }
 
 func GetResourceWatcherCli() ResourceWatcherCli {
 	r := ResourceWatcherCli{}
-	r.watchResources = viper.GetStringSlice("watch-resources")
-	r.watchNamespaces = viper.GetStringSlice("watch-namespaces")
-	r.excludResources = viper.GetStringSlice("exclude-resources")
-	r.excludNamespaces = viper.GetStringSlice("exclude-namespaces")
-	r.ignoreNodeRoles = viper.GetStringSlice("ignore-node-roles")
-	r.nodePollingPeriod = viper.GetDuration("node-polling-period")
-	r.namespacePollingPeriod = viper.GetDuration("namespace-polling-period")
+	r.WatchResources = viper.GetStringSlice("watch-resources")
+	r.WatchNamespaces = viper.GetStringSlice("watch-namespaces")
+	r.ExcludeResources = viper.GetStringSlice("exclude-resources")
+	r.ExcludeNamespaces = viper.GetStringSlice("exclude-namespaces")
+	r.IgnoreNodeRoles = viper.GetStringSlice("ignore-node-roles")
+	r.NodePollingPeriod = viper.GetDuration("node-polling-period")
+	r.NamespacePollingPeriod = viper.GetDuration("namespace-polling-period")
+	r.ExitOnUnauthorized = viper.GetBool("exit-on-unauthorized")
+	r.AccessReview = viper.GetBool("access-review")
+	r.LegacyEndpoints = viper.GetBool("legacy-endpoints")
+	r.HideSecretKeys = viper.GetBool("hide-secret-keys")
+	r.ExcludeAnnotations = viper.GetStringSlice("exclude-annotations")
 	return r
 }
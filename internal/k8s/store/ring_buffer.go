@@ -0,0 +1,43 @@
+package store
+
+import "container/list"
+
+// keyRing keeps the update order of a bounded number of keys.
+// When full, adding a new key evicts the least recently updated one.
+type keyRing struct {
+	size  int
+	keys  *list.List               // Oldest key first
+	index map[string]*list.Element // key to its element in keys
+}
+
+func newKeyRing(size int) *keyRing {
+	return &keyRing{
+		size:  size,
+		keys:  list.New(),
+		index: make(map[string]*list.Element, size),
+	}
+}
+
+// add inserts or moves the key to the back and returns the evicted key, if any
+func (r *keyRing) add(key string) (string, bool) {
+	if elem, ok := r.index[key]; ok {
+		r.keys.MoveToBack(elem)
+		return "", false
+	}
+	r.index[key] = r.keys.PushBack(key)
+	if r.keys.Len() <= r.size {
+		return "", false
+	}
+	evicted := r.keys.Remove(r.keys.Front()).(string)
+	delete(r.index, evicted)
+	return evicted, true
+}
+
+func (r *keyRing) remove(key string) {
+	elem, ok := r.index[key]
+	if !ok {
+		return
+	}
+	r.keys.Remove(elem)
+	delete(r.index, key)
+}
@@ -10,9 +10,11 @@ import (
 )
 
 type Stats struct {
+	Cluster          string
 	ResourceType     resources.ResourceType
 	ItemPerNamespace map[string]int
 	LastDumped       time.Time
+	Access           string
 }
 
 func GetStatsFromStores(stores []*Store) []*Stats {
@@ -26,23 +28,34 @@ func GetStatsFromStores(stores []*Store) []*Stats {
 func (s *Stats) toTabOutput() []string {
 	strings := make([]string, 0)
 	now := time.Now()
+	access := s.Access
+	if access == "" {
+		access = "None"
+	}
+	deltaDate := now.Sub(s.LastDumped).Truncate(time.Second)
 	for namespace, numItems := range s.ItemPerNamespace {
-		deltaDate := now.Sub(s.LastDumped).Truncate(time.Second)
-		line := fmt.Sprintf("%s\t%s\t%d\t%s",
+		line := fmt.Sprintf("%s\t%s\t%s\t%d\t%s\t%s",
+			s.Cluster,
 			s.ResourceType.String(),
 			namespace,
 			numItems,
 			deltaDate,
+			access,
 		)
 		strings = append(strings, line)
 	}
+	if len(s.ItemPerNamespace) == 0 {
+		// Keep a line for empty or forbidden resources
+		line := fmt.Sprintf("%s\t%s\tNone\t0\tNone\t%s", s.Cluster, s.ResourceType.String(), access)
+		strings = append(strings, line)
+	}
 	return strings
 }
 
 func GetStatsOutput(stats []*Stats) string {
 	b := new(strings.Builder)
 	w := tabwriter.NewWriter(b, 0, 0, 1, ' ', tabwriter.StripEscape)
-	fmt.Fprintln(w, "Resource\tNamespace\tNumber\tLast Dumped")
+	fmt.Fprintln(w, "Cluster\tResource\tNamespace\tNumber\tLast Dumped\tAccess")
 	for _, s := range stats {
 		for _, line := range s.toTabOutput() {
 			fmt.Fprintln(w, line)
@@ -3,8 +3,10 @@ package store
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
@@ -31,6 +33,16 @@ type Store struct {
 
 	dumpRequired bool
 	lastFullDump time.Time
+	lastModified time.Time // Last change of the data, dataMutex needs to be held
+	access       string
+
+	stopOnce sync.Once
+	stop     chan struct{}
+
+	syncPending       int32 // Number of informers or pollers left before the initial listing is complete
+	sharedStateLoaded bool  // The leader's shared state is served until the first dump
+
+	ring *keyRing // Bounds the number of kept resources, only used for events
 }
 
 // NewStore creates a new store
@@ -45,7 +57,14 @@ func NewStore(ctx context.Context, storeConfig *StoreConfig,
 	k.firstWrite = true
 	k.ctorConfig = ctorConfig
 	k.lastFullDump = time.Time{}
-	go k.fullDumpTicker()
+	k.stop = make(chan struct{})
+	k.syncPending = 1
+	if resourceType == resources.ResourceTypeEvent {
+		k.ring = newKeyRing(storeConfig.GetEventBufferSize())
+	} else {
+		k.sharedStateLoaded = storeConfig.LoadSharedState(resourceType)
+		go k.fullDumpTicker()
+	}
 
 	return &k
 }
@@ -54,13 +73,26 @@ func (k *Store) fullDumpTicker() {
 	timeBetweenFullDump := k.storeConfig.GetTimeBetweenFullDump()
 	logrus.Debugf("Starting ticker loop for %s: will do full dump every %s", k.resourceType, timeBetweenFullDump)
 	t := time.NewTicker(timeBetweenFullDump)
+	defer t.Stop()
 	for {
-		<-t.C
-		err := k.DumpFullState()
-		util.FatalIf(err)
+		select {
+		case <-k.stop:
+			logrus.Debugf("Stopping ticker loop for %s", k.resourceType)
+			return
+		case <-t.C:
+			err := k.DumpFullState()
+			util.FatalIf(err)
+		}
 	}
 }
 
+// Stop ends the full dump ticker of the store
+func (k *Store) Stop() {
+	k.stopOnce.Do(func() {
+		close(k.stop)
+	})
+}
+
 func resourceKey(obj interface{}) string {
 	name := "None"
 	namespace := "None"
@@ -84,21 +116,38 @@ func resourceKey(obj interface{}) string {
 // This is used for polled resources, no need for mutex
 func (k *Store) AddResourceList(lstRuntime []runtime.Object) {
 	k.data = make(map[string]resources.K8sResource, 0)
+	if k.ring != nil {
+		k.ring = newKeyRing(k.ring.size)
+	}
 	for _, runtimeObject := range lstRuntime {
 		key := resourceKey(runtimeObject)
 		resource := k.resourceCtor(runtimeObject, k.ctorConfig)
-		k.data[key] = resource
+		k.setResource(key, resource)
 	}
+	k.lastModified = time.Now()
 	k.dumpRequired = true
 }
 
+// setResource stores the resource, evicting the oldest one if the store is bounded.
+// dataMutex needs to be held
+func (k *Store) setResource(key string, resource resources.K8sResource) {
+	if k.ring != nil {
+		if evicted, ok := k.ring.add(key); ok {
+			logrus.Tracef("%s evicted: %s", k.resourceType, evicted)
+			delete(k.data, evicted)
+		}
+	}
+	k.data[key] = resource
+	k.lastModified = time.Now()
+}
+
 // AddResource adds a new k8s object to the store
 func (k *Store) AddResource(obj interface{}) {
 	key := resourceKey(obj)
 	newObj := k.resourceCtor(obj, k.ctorConfig)
 	logrus.Tracef("%s added: %s", k.resourceType, key)
 	k.dataMutex.Lock()
-	k.data[key] = newObj
+	k.setResource(key, newObj)
 	k.dataMutex.Unlock()
 	k.dumpRequired = true
 }
@@ -119,6 +168,10 @@ func (k *Store) DeleteResource(obj interface{}) {
 	logrus.Tracef("%s deleted: %s", k.resourceType, key)
 	k.dataMutex.Lock()
 	delete(k.data, key)
+	if k.ring != nil {
+		k.ring.remove(key)
+	}
+	k.lastModified = time.Now()
 	k.dataMutex.Unlock()
 	k.dumpRequired = true
 }
@@ -130,7 +183,7 @@ func (k *Store) UpdateResource(oldObj, newObj interface{}) {
 	k.dataMutex.Lock()
 	if k8sObj.HasChanged(k.data[key]) {
 		logrus.Tracef("%s changed: %s", k.resourceType, key)
-		k.data[key] = k8sObj
+		k.setResource(key, k8sObj)
 		k.dataMutex.Unlock()
 		k.dumpRequired = true
 	} else {
@@ -138,6 +191,39 @@ func (k *Store) UpdateResource(oldObj, newObj interface{}) {
 	}
 }
 
+// GetResourceType returns the type of resources kept in the store
+func (k *Store) GetResourceType() resources.ResourceType {
+	return k.resourceType
+}
+
+// SetSyncPending sets the number of initial listings to wait for before the store is synced
+func (k *Store) SetSyncPending(n int) {
+	atomic.StoreInt32(&k.syncPending, int32(n))
+}
+
+// MarkSynced records the end of an initial listing
+func (k *Store) MarkSynced() {
+	if atomic.AddInt32(&k.syncPending, -1) == 0 {
+		logrus.Infof("Store %s synced", k.resourceType)
+	}
+}
+
+// IsSynced returns true once all initial listings are done
+func (k *Store) IsSynced() bool {
+	return atomic.LoadInt32(&k.syncPending) <= 0
+}
+
+// IsReady returns true once the store can serve its resources, either
+// from its initial listing or from the shared state of the leader
+func (k *Store) IsReady() bool {
+	return k.IsSynced() || k.sharedStateLoaded
+}
+
+// SetAccess records the result of the access review done before watching the resource
+func (k *Store) SetAccess(access string) {
+	k.access = access
+}
+
 func (k *Store) GetStats() *Stats {
 	itemPerNamespaces := make(map[string]int, 0)
 	for _, r := range k.data {
@@ -150,14 +236,45 @@ func (k *Store) GetStats() *Stats {
 		}
 	}
 	return &Stats{
+		Cluster:          k.storeConfig.GetContext(),
 		ResourceType:     k.resourceType,
 		ItemPerNamespace: itemPerNamespaces,
 		LastDumped:       k.lastFullDump,
+		Access:           k.access,
 	}
 }
 
+// IsInMemory returns true if the store is only served from memory.
+// Events churn too much to go through full dumps.
+func (k *Store) IsInMemory() bool {
+	return k.ring != nil
+}
+
+// GetCluster returns the cluster watched by the store
+func (k *Store) GetCluster() string {
+	return k.storeConfig.GetContext()
+}
+
+// EncodeState writes the current state of the store in the cache file format
+func (k *Store) EncodeState(w io.Writer) error {
+	k.dataMutex.Lock()
+	defer k.dataMutex.Unlock()
+	return util.EncodeGob(k.data, w)
+}
+
+// GetLastModified returns the time of the last change of the store
+func (k *Store) GetLastModified() time.Time {
+	k.dataMutex.Lock()
+	defer k.dataMutex.Unlock()
+	return k.lastModified
+}
+
 // DumpFullState writes the full state to the cache file
 func (k *Store) DumpFullState() error {
+	if k.IsInMemory() {
+		logrus.Tracef("%s are only kept in memory, skipping dump", k.resourceType)
+		return nil
+	}
 	if !k.dumpRequired {
 		logrus.Tracef("No change of %s detected, skipping dump", k.resourceType)
 		return nil
@@ -173,7 +290,20 @@ func (k *Store) DumpFullState() error {
 	logrus.Infof("Doing full dump of %d %s", len(k.data), k.resourceType)
 	destFile := k.storeConfig.GetResourceStorePath(k.resourceType)
 	k.dataMutex.Lock()
-	err := util.EncodeToFile(k.data, destFile)
-	k.dataMutex.Unlock()
-	return err
+	defer k.dataMutex.Unlock()
+	data := resources.MergeResources(k.data)
+	err := util.EncodeToFile(data, destFile)
+	if err != nil {
+		return err
+	}
+	k.storeConfig.WriteSharedState(k.resourceType, data)
+	return nil
+}
+
+// ForceDumpFullState writes the full state to the cache file, ignoring
+// the time between full dumps
+func (k *Store) ForceDumpFullState() error {
+	k.dumpRequired = true
+	k.lastFullDump = time.Time{}
+	return k.DumpFullState()
 }
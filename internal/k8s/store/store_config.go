@@ -1,9 +1,15 @@
 package store
 
 import (
+	"io"
+	"os"
+	"path"
 	"time"
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/clusterconfig"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
+	"github.com/sirupsen/logrus"
 )
 
 // StoreConfig defines configuration to store
@@ -11,15 +17,90 @@ import (
 type StoreConfig struct {
 	clusterconfig.ClusterConfig
 	timeBetweenFullDump time.Duration
+	sharedCacheDir      string
+	eventBufferSize     int
+	isLeader            func() bool
 }
 
 func NewStoreConfig(storeConfigCli *StoreConfigCli) *StoreConfig {
 	s := StoreConfig{}
 	s.ClusterConfig = clusterconfig.NewClusterConfig(storeConfigCli.ClusterConfigCli)
 	s.timeBetweenFullDump = storeConfigCli.TimeBetweenFullDump
+	s.sharedCacheDir = storeConfigCli.SharedCacheDir
+	s.eventBufferSize = storeConfigCli.EventBufferSize
 	return &s
 }
 
 func (s *StoreConfig) GetTimeBetweenFullDump() time.Duration {
 	return s.timeBetweenFullDump
 }
+
+// GetEventBufferSize returns the maximum number of events kept
+func (s *StoreConfig) GetEventBufferSize() int {
+	if s.eventBufferSize <= 0 {
+		return 1000
+	}
+	return s.eventBufferSize
+}
+
+// SetLeaderCheck sets the function telling if this instance holds the leader lease.
+// Without leader check, the instance is considered the leader.
+func (s *StoreConfig) SetLeaderCheck(isLeader func() bool) {
+	s.isLeader = isLeader
+}
+
+// IsLeader returns true if this instance is allowed to write shared state
+func (s *StoreConfig) IsLeader() bool {
+	return s.isLeader == nil || s.isLeader()
+}
+
+func (s *StoreConfig) getSharedStatePath(r resources.ResourceType) string {
+	return path.Join(s.sharedCacheDir, s.GetContext(), r.String())
+}
+
+// WriteSharedState writes the resources in the shared cache dir.
+// Only the leader writes the shared state. Errors are only logged as the
+// local dump is already done.
+func (s *StoreConfig) WriteSharedState(r resources.ResourceType, data interface{}) {
+	if s.sharedCacheDir == "" || !s.IsLeader() {
+		return
+	}
+	destFile := s.getSharedStatePath(r)
+	err := os.MkdirAll(path.Dir(destFile), os.ModePerm)
+	if err != nil {
+		logrus.Warnf("Error creating shared cache dir: %s", err)
+		return
+	}
+	logrus.Debugf("Writing shared state of %s to %s", r, destFile)
+	err = util.EncodeToFile(data, destFile)
+	if err != nil {
+		logrus.Warnf("Error writing shared state of %s: %s", r, err)
+	}
+}
+
+// LoadSharedState copies the shared state written by the leader in the
+// destination dir if the resource wasn't dumped yet, allowing a new replica
+// to serve resources before the end of its initial listing.
+// It returns true if the shared state was loaded.
+func (s *StoreConfig) LoadSharedState(r resources.ResourceType) bool {
+	if s.sharedCacheDir == "" || s.FileStoreExists(r) {
+		return false
+	}
+	b, err := os.ReadFile(s.getSharedStatePath(r))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			logrus.Warnf("Error reading shared state of %s: %s", r, err)
+		}
+		return false
+	}
+	err = util.WriteFileAtomic(s.GetResourceStorePath(r), func(w io.Writer) error {
+		_, err := w.Write(b)
+		return err
+	})
+	if err != nil {
+		logrus.Warnf("Error loading shared state of %s: %s", r, err)
+		return false
+	}
+	logrus.Infof("Loaded shared state of %s", r)
+	return true
+}
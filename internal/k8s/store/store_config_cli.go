@@ -11,11 +11,15 @@ import (
 type StoreConfigCli struct {
 	*clusterconfig.ClusterConfigCli
 	TimeBetweenFullDump time.Duration
+	SharedCacheDir      string
+	EventBufferSize     int
 }
 
 func SetStoreConfigCli(fs *pflag.FlagSet) {
 	clusterconfig.SetClusterConfigCli(fs)
 	fs.Duration("time-between-full-dump", 10*time.Second, "Buffer changes and only do full dump every x secondes")
+	fs.Int("event-buffer-size", 1000, "Maximum number of events kept, oldest events are evicted first.")
+	fs.String("shared-cache-dir", "", "Cache dir shared between replicas. Only the elected leader writes in it, starting replicas load it.")
 }
 
 func GetStoreConfigCli() StoreConfigCli {
@@ -23,5 +27,7 @@ func GetStoreConfigCli() StoreConfigCli {
 		ClusterConfigCli: clusterconfig.GetClusterConfigCli(),
 	}
 	s.TimeBetweenFullDump = viper.GetDuration("time-between-full-dump")
+	s.SharedCacheDir = viper.GetString("shared-cache-dir")
+	s.EventBufferSize = viper.GetInt("event-buffer-size")
 	return s
 }
@@ -1,15 +1,21 @@
 package store
 
 import (
+	"context"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
 	"testing"
+	"time"
 
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/clusterconfig"
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestMain(m *testing.M) {
@@ -41,3 +47,81 @@ func TestDumpAPIResources(t *testing.T) {
 	err = util.LoadGobFromFile(&loadResource, apiResourcesFilePath)
 	require.NoError(t, err)
 }
+
+func TestEventStoreEviction(t *testing.T) {
+	storeConfigCli := &StoreConfigCli{
+		ClusterConfigCli:    &clusterconfig.ClusterConfigCli{ClusterName: "test", CacheDir: t.TempDir()},
+		TimeBetweenFullDump: time.Minute,
+		EventBufferSize:     2,
+	}
+	storeConfig := NewStoreConfig(storeConfigCli)
+	s := NewStore(context.Background(), storeConfig, resources.CtorConfig{}, resources.ResourceTypeEvent)
+	defer s.Stop()
+
+	events := []*corev1.Event{}
+	for i := 0; i < 3; i++ {
+		event := &corev1.Event{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: fmt.Sprintf("event-%d", i)}}
+		events = append(events, event)
+		s.AddResource(event)
+	}
+	require.Len(t, s.data, 2)
+	require.NotContains(t, s.data, "default_event-0")
+	require.Contains(t, s.data, "default_event-2")
+
+	// The oldest event was deleted, its slot is reused without eviction
+	s.DeleteResource(events[1])
+	s.AddResource(events[0])
+	require.Len(t, s.data, 2)
+	require.Contains(t, s.data, "default_event-2")
+	require.Contains(t, s.data, "default_event-0")
+
+	// Deleting the newest event frees room without evicting the oldest one
+	s.DeleteResource(events[0])
+	s.AddResource(events[1])
+	require.Len(t, s.data, 2)
+	require.Contains(t, s.data, "default_event-2")
+	require.Contains(t, s.data, "default_event-1")
+
+	// An updated event is kept over older ones
+	updated := events[2].DeepCopy()
+	updated.Count = 2
+	lastModified := s.GetLastModified()
+	s.UpdateResource(events[2], updated)
+	require.False(t, s.GetLastModified().Before(lastModified))
+	s.AddResource(events[0])
+	require.Len(t, s.data, 2)
+	require.Contains(t, s.data, "default_event-2")
+	require.Contains(t, s.data, "default_event-0")
+
+	// Events are not dumped
+	require.NoError(t, s.ForceDumpFullState())
+	require.False(t, storeConfig.FileStoreExists(resources.ResourceTypeEvent))
+}
+
+func TestSharedState(t *testing.T) {
+	sharedCacheDir := t.TempDir()
+	leaderConfig := NewStoreConfig(&StoreConfigCli{
+		ClusterConfigCli:    &clusterconfig.ClusterConfigCli{ClusterName: "test", CacheDir: t.TempDir()},
+		TimeBetweenFullDump: time.Minute,
+		SharedCacheDir:      sharedCacheDir,
+	})
+	pods := map[string]resources.K8sResource{"default_pod": &resources.Pod{}}
+	leaderConfig.WriteSharedState(resources.ResourceTypePod, pods)
+
+	followerConfig := NewStoreConfig(&StoreConfigCli{
+		ClusterConfigCli:    &clusterconfig.ClusterConfigCli{ClusterName: "test", CacheDir: t.TempDir()},
+		TimeBetweenFullDump: time.Minute,
+		SharedCacheDir:      sharedCacheDir,
+	})
+	require.NoError(t, followerConfig.CreateDestDir())
+	s := NewStore(context.Background(), followerConfig, resources.CtorConfig{}, resources.ResourceTypePod)
+	defer s.Stop()
+	require.False(t, s.IsSynced())
+	require.True(t, s.IsReady())
+	require.True(t, followerConfig.FileStoreExists(resources.ResourceTypePod))
+
+	// Nothing to load for resources not shared by the leader
+	s = NewStore(context.Background(), followerConfig, resources.CtorConfig{}, resources.ResourceTypeNode)
+	defer s.Stop()
+	require.False(t, s.IsReady())
+}
@@ -0,0 +1,159 @@
+package kubectlfzfserver
+
+import (
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resourcewatcher"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/store"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// clusterWatcher is a running watcher on a cluster kept for later switches
+type clusterWatcher struct {
+	fingerprint string
+	storeConfig *store.StoreConfig
+	watcher     *resourcewatcher.ResourceWatcher
+	lastUsed    time.Time
+}
+
+// currentWatcher returns the watcher of the current cluster
+func (k *kubectlFzfServer) currentWatcher() *resourcewatcher.ResourceWatcher {
+	return k.clusters[k.currentCluster].watcher
+}
+
+// switchCluster makes the cluster of the loaded kubeconfig the current one.
+// A kept watcher is reused if its server and credentials didn't change.
+func (k *kubectlFzfServer) switchCluster() error {
+	k.watcherMutex.Lock()
+	defer k.watcherMutex.Unlock()
+	cluster := k.storeConfig.GetContext()
+	fingerprint := k.storeConfig.GetFingerprint()
+	if c, ok := k.clusters[cluster]; ok {
+		if c.fingerprint == fingerprint {
+			logrus.Infof("Cluster %s is already watched, reusing its cache", cluster)
+			c.lastUsed = time.Now()
+			k.currentCluster = cluster
+			k.refreshStores()
+			return nil
+		}
+		logrus.Infof("Server or credentials of cluster %s changed, restarting its watcher", cluster)
+		k.evictCluster(cluster)
+	}
+
+	clusterStoreConfig, err := k.newClusterStoreConfig()
+	if err != nil {
+		return err
+	}
+	watcher, _, err := startWatchOnCluster(k.ctx, k.resourceWatcherCli, clusterStoreConfig)
+	if err != nil {
+		return err
+	}
+	k.clusters[cluster] = &clusterWatcher{
+		fingerprint: fingerprint,
+		storeConfig: clusterStoreConfig,
+		watcher:     watcher,
+		lastUsed:    time.Now(),
+	}
+	k.currentCluster = cluster
+	k.enforceLimitsLocked()
+	k.refreshStores()
+	return nil
+}
+
+// newClusterStoreConfig builds the store config of the current cluster.
+// Each cluster needs its own config as it keeps its own destination dir.
+func (k *kubectlFzfServer) newClusterStoreConfig() (*store.StoreConfig, error) {
+	storeConfig := store.NewStoreConfig(&k.storeConfigCli)
+	err := storeConfig.LoadClusterConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "error loading cluster config")
+	}
+	err = storeConfig.CreateDestDir()
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating destination dir")
+	}
+	storeConfig.SetLeaderCheck(k.isLeader)
+	return storeConfig, nil
+}
+
+func (k *kubectlFzfServer) evictCluster(cluster string) {
+	logrus.Infof("Stopping watcher of cluster %s", cluster)
+	k.clusters[cluster].watcher.Stop()
+	delete(k.clusters, cluster)
+}
+
+// getClustersByLastUse returns the kept clusters, least recently used first
+func (k *kubectlFzfServer) getClustersByLastUse() []string {
+	clusters := make([]string, 0, len(k.clusters))
+	for cluster := range k.clusters {
+		clusters = append(clusters, cluster)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		return k.clusters[clusters[i]].lastUsed.Before(k.clusters[clusters[j]].lastUsed)
+	})
+	return clusters
+}
+
+func getHeapSize() uint64 {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+func (k *kubectlFzfServer) isOverMemoryBudget() bool {
+	if k.kubectlFzfServerCli.ClusterMemoryBudget == 0 {
+		return false
+	}
+	heapSize := getHeapSize()
+	logrus.Debugf("Heap size %d, memory budget %d", heapSize, k.kubectlFzfServerCli.ClusterMemoryBudget)
+	return heapSize > uint64(k.kubectlFzfServerCli.ClusterMemoryBudget)
+}
+
+// enforceLimitsLocked evicts least recently used clusters until the
+// number of clusters and the heap size are within limits.
+// Memory of stopped watchers is only freed by a gc, so one is run after an
+// eviction done to get under the memory budget before checking it again.
+// The current cluster is never evicted.
+func (k *kubectlFzfServer) enforceLimitsLocked() {
+	overMemoryBudget := k.isOverMemoryBudget()
+	for _, cluster := range k.getClustersByLastUse() {
+		if cluster == k.currentCluster {
+			continue
+		}
+		if len(k.clusters) <= k.kubectlFzfServerCli.MaxClusters && !overMemoryBudget {
+			return
+		}
+		k.evictCluster(cluster)
+		if overMemoryBudget {
+			runtime.GC()
+			overMemoryBudget = k.isOverMemoryBudget()
+		}
+	}
+}
+
+func (k *kubectlFzfServer) enforceLimits() {
+	k.watcherMutex.Lock()
+	defer k.watcherMutex.Unlock()
+	numClusters := len(k.clusters)
+	k.enforceLimitsLocked()
+	if len(k.clusters) != numClusters {
+		k.refreshStores()
+	}
+}
+
+// refreshStores sends the stores of all kept clusters to the http server
+func (k *kubectlFzfServer) refreshStores() {
+	if k.fzfHttpServer == nil {
+		return
+	}
+	stores := k.currentWatcher().GetStores()
+	for cluster, c := range k.clusters {
+		if cluster != k.currentCluster {
+			stores = append(stores, c.watcher.GetStores()...)
+		}
+	}
+	k.fzfHttpServer.SetStores(stores)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
@@ -11,11 +12,14 @@ import (
 	_ "net/http/pprof"
 
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/httpserver"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/clusterconfig"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resourcewatcher"
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/store"
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 )
 
 func startWatchOnCluster(ctx context.Context,
@@ -34,6 +38,10 @@ func startWatchOnCluster(ctx context.Context,
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "error getting watchdog configs")
 	}
+	err = watcher.ReviewAccess(ctx, watchConfigs)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error reviewing access")
+	}
 	logrus.Infof("Start cache build on cluster %s", cluster)
 	stores := make([]*store.Store, 0)
 	for _, watchConfig := range watchConfigs {
@@ -47,7 +55,87 @@ func startWatchOnCluster(ctx context.Context,
 	return watcher, stores, nil
 }
 
-func handleSignals(cancel context.CancelFunc) {
+// kubectlFzfServer keeps the watchers of the recently used clusters and
+// implements the admin controller
+type kubectlFzfServer struct {
+	ctx                 context.Context
+	storeConfigCli      store.StoreConfigCli
+	storeConfig         *store.StoreConfig
+	isLeader            func() bool
+	fzfHttpServer       *httpserver.FzfHttpServer
+	resourceWatcherCli  resourcewatcher.ResourceWatcherCli
+	kubectlFzfServerCli KubectlFzfServerCli
+
+	watcherMutex   sync.Mutex
+	clusters       map[string]*clusterWatcher
+	currentCluster string
+}
+
+func (k *kubectlFzfServer) GetResourceWatcherCli() resourcewatcher.ResourceWatcherCli {
+	k.watcherMutex.Lock()
+	defer k.watcherMutex.Unlock()
+	return k.resourceWatcherCli
+}
+
+func (k *kubectlFzfServer) UpdateResourceWatcherCli(update func(resourcewatcher.ResourceWatcherCli) (resourcewatcher.ResourceWatcherCli, error)) error {
+	k.watcherMutex.Lock()
+	defer k.watcherMutex.Unlock()
+	resourceWatcherCli, err := update(k.resourceWatcherCli)
+	if err != nil {
+		return err
+	}
+	// Nothing is applied until the configuration is valid for all clusters
+	plans := make(map[string]*resourcewatcher.ReconcilePlan, len(k.clusters))
+	for cluster, c := range k.clusters {
+		plan, err := c.watcher.PrepareReconcile(k.ctx, resourceWatcherCli)
+		if err != nil {
+			return errors.Wrapf(err, "error reconciling watchers of cluster %s", cluster)
+		}
+		plans[cluster] = plan
+	}
+	for cluster, c := range k.clusters {
+		c.watcher.ApplyReconcile(k.ctx, plans[cluster])
+	}
+	k.resourceWatcherCli = resourceWatcherCli
+	k.refreshStores()
+	return nil
+}
+
+func (k *kubectlFzfServer) ResyncResource(resourceType resources.ResourceType) error {
+	k.watcherMutex.Lock()
+	defer k.watcherMutex.Unlock()
+	err := k.currentWatcher().Resync(k.ctx, resourceType)
+	if err != nil {
+		return err
+	}
+	k.refreshStores()
+	return nil
+}
+
+func (k *kubectlFzfServer) DumpResource(resourceType resources.ResourceType) error {
+	k.watcherMutex.Lock()
+	defer k.watcherMutex.Unlock()
+	return k.currentWatcher().Dump(resourceType)
+}
+
+// reloadConfig reads the configuration file again and applies it to the watchers
+func (k *kubectlFzfServer) reloadConfig() {
+	err := viper.ReadInConfig()
+	if _, ok := err.(viper.ConfigFileNotFoundError); err != nil && !ok {
+		logrus.Errorf("Error reading configuration: %s", err)
+		return
+	}
+	err = k.UpdateResourceWatcherCli(func(resourcewatcher.ResourceWatcherCli) (resourcewatcher.ResourceWatcherCli, error) {
+		return resourcewatcher.GetResourceWatcherCli(), nil
+	})
+	if err != nil {
+		logrus.Errorf("Error reloading configuration: %s", err)
+		return
+	}
+	logrus.Infof("Configuration reloaded")
+}
+
+func handleSignals(cancel context.CancelFunc, reload chan struct{}) {
 	sigIn := make(chan os.Signal, 100)
 	signal.Notify(sigIn)
 	for sig := range sigIn {
@@ -55,13 +143,17 @@ func handleSignals(cancel context.CancelFunc) {
 		case syscall.SIGINT, syscall.SIGTERM:
 			logrus.Errorf("Caught signal '%s' (%d); terminating.", sig, sig)
 			cancel()
+		case syscall.SIGHUP:
+			logrus.Infof("Caught signal '%s' (%d); reloading configuration.", sig, sig)
+			reload <- struct{}{}
 		}
 	}
 }
 
 func StartKubectlFzfServer() {
 	ctx, cancel := context.WithCancel(context.Background())
-	go handleSignals(cancel)
+	reload := make(chan struct{}, 1)
+	go handleSignals(cancel, reload)
 
 	storeConfigCli := store.GetStoreConfigCli()
 	storeConfig := store.NewStoreConfig(&storeConfigCli)
@@ -74,43 +166,80 @@ func StartKubectlFzfServer() {
 		logrus.Fatalf("error creating destination dir: %s", err)
 	}
 
-	resourceWatcherCli := resourcewatcher.GetResourceWatcherCli()
-	watcher, stores, err := startWatchOnCluster(ctx, resourceWatcherCli, storeConfig)
+	k := &kubectlFzfServer{
+		ctx:                 ctx,
+		storeConfigCli:      storeConfigCli,
+		storeConfig:         storeConfig,
+		resourceWatcherCli:  resourcewatcher.GetResourceWatcherCli(),
+		kubectlFzfServerCli: GetKubectlFzfServerCli(),
+		clusters:            make(map[string]*clusterWatcher, 0),
+	}
+	k.isLeader, err = startLeaderElection(ctx, k.kubectlFzfServerCli, storeConfig)
+	if err != nil {
+		logrus.Fatalf("Error starting leader election: %s", err)
+	}
+	err = k.switchCluster()
 	util.FatalIf(err)
-	ticker := time.NewTicker(time.Second * 5)
 
 	httpServerConfCli := httpserver.GetHttpServerConfigCli()
-	_, err = httpserver.StartHttpServer(ctx, &httpServerConfCli, storeConfig, stores)
+	k.fzfHttpServer, err = httpserver.StartHttpServer(ctx, &httpServerConfCli, storeConfig, k.currentWatcher().GetStores())
 	if err != nil {
 		logrus.Fatalf("Error starting http server: %s", err)
 	}
+	_, err = httpserver.StartAdminServer(ctx, &httpServerConfCli, k)
+	if err != nil {
+		logrus.Fatalf("Error starting admin server: %s", err)
+	}
 
 	go func() {
 		logrus.Println(http.ListenAndServe("localhost:6060", nil))
 	}()
 
 	currentContext := storeConfig.GetContext()
+	currentFingerprint := storeConfig.GetFingerprint()
+	watcherCtx, watcherCancel := context.WithCancel(ctx)
+	kubeconfigWatcher, err := clusterconfig.NewKubeconfigWatcher(watcherCtx, &storeConfig.ClusterConfig)
+	util.FatalIf(err)
+	memoryTicker := time.NewTicker(time.Minute)
 	for {
 		select {
 		case <-ctx.Done():
 			logrus.Info("Context done, exiting")
+			watcherCancel()
 			return
-		case <-ticker.C:
+		case <-reload:
+			k.reloadConfig()
+		case <-memoryTicker.C:
+			k.enforceLimits()
+		case <-kubeconfigWatcher.Events():
 			err = storeConfig.LoadClusterConfig()
-			util.FatalIf(err)
+			if err != nil {
+				logrus.Errorf("Error reloading kubeconfig, keeping current watchers: %s", err)
+				continue
+			}
 			newContext := storeConfig.GetContext()
+			newFingerprint := storeConfig.GetFingerprint()
 			logrus.Debugf("Checking config %s %s ", currentContext, newContext)
 			if newContext != currentContext {
 				logrus.Infof("Detected context change %s != %s", newContext, currentContext)
-				watcher.Stop()
 				err = storeConfig.CreateDestDir()
 				if err != nil {
 					logrus.Fatalf("error creating destination dir: %s", err)
 				}
-				watcher, _, err = startWatchOnCluster(ctx, resourceWatcherCli, storeConfig)
-				util.FatalIf(err)
-				currentContext = newContext
+			} else if newFingerprint != currentFingerprint {
+				logrus.Infof("Detected server or credentials change of context %s", newContext)
+			} else {
+				continue
 			}
+			err = k.switchCluster()
+			util.FatalIf(err)
+			currentContext = newContext
+			currentFingerprint = newFingerprint
+			// Credential files may differ with the new configuration
+			watcherCancel()
+			watcherCtx, watcherCancel = context.WithCancel(ctx)
+			kubeconfigWatcher, err = clusterconfig.NewKubeconfigWatcher(watcherCtx, &storeConfig.ClusterConfig)
+			util.FatalIf(err)
 		}
 	}
 }
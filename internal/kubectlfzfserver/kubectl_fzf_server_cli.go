@@ -0,0 +1,48 @@
+package kubectlfzfserver
+
+import (
+	"os"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+type KubectlFzfServerCli struct {
+	MaxClusters         int
+	ClusterMemoryBudget uint
+
+	LeaderElect              bool
+	LeaderElectLeaseName     string
+	LeaderElectNamespace     string
+	LeaderElectIdentity      string
+	LeaderElectLeaseDuration time.Duration
+}
+
+func SetKubectlFzfServerCli(fs *pflag.FlagSet) {
+	fs.Int("max-clusters", 1, "Number of clusters kept watched. Switching back to a kept cluster serves its cache immediately.")
+	fs.String("cluster-memory-budget", "", "Heap size above which the least recently used clusters are evicted, like 512MB. Empty for no limit.")
+	fs.Bool("leader-elect", false, "Elect a leader between replicas using a Lease. Only the leader writes the shared state.")
+	fs.String("leader-elect-lease-name", "kubectl-fzf", "Name of the Lease used for leader election.")
+	fs.String("leader-elect-namespace", os.Getenv("POD_NAMESPACE"), "Namespace of the Lease used for leader election.")
+	fs.String("leader-elect-identity", os.Getenv("POD_NAME"), "Identity of this replica in the leader election. Defaults to the hostname.")
+	fs.Duration("leader-elect-lease-duration", 15*time.Second, "Duration of the leader Lease.")
+}
+
+func GetKubectlFzfServerCli() KubectlFzfServerCli {
+	k := KubectlFzfServerCli{}
+	k.MaxClusters = viper.GetInt("max-clusters")
+	if k.MaxClusters < 1 {
+		k.MaxClusters = 1
+	}
+	k.ClusterMemoryBudget = viper.GetSizeInBytes("cluster-memory-budget")
+	k.LeaderElect = viper.GetBool("leader-elect")
+	k.LeaderElectLeaseName = viper.GetString("leader-elect-lease-name")
+	k.LeaderElectNamespace = viper.GetString("leader-elect-namespace")
+	k.LeaderElectIdentity = viper.GetString("leader-elect-identity")
+	if k.LeaderElectIdentity == "" {
+		k.LeaderElectIdentity, _ = os.Hostname()
+	}
+	k.LeaderElectLeaseDuration = viper.GetDuration("leader-elect-lease-duration")
+	return k
+}
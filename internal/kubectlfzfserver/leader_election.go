@@ -0,0 +1,88 @@
+package kubectlfzfserver
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/store"
+	"github.com/sirupsen/logrus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+)
+
+// leaderState tracks if this replica currently holds the leader Lease
+type leaderState struct {
+	leader int32
+}
+
+func (l *leaderState) isLeader() bool {
+	return atomic.LoadInt32(&l.leader) == 1
+}
+
+func (l *leaderState) setLeader(leader bool) {
+	v := int32(0)
+	if leader {
+		v = 1
+	}
+	atomic.StoreInt32(&l.leader, v)
+}
+
+// startLeaderElection runs the Lease based leader election until ctx is done.
+// Every replica keeps serving reads, only the leader writes the shared state.
+// It returns the leader check to set on the store configs, nil without leader election.
+func startLeaderElection(ctx context.Context, k KubectlFzfServerCli, storeConfig *store.StoreConfig) (func() bool, error) {
+	if !k.LeaderElect {
+		return nil, nil
+	}
+	if k.LeaderElectNamespace == "" {
+		return nil, fmt.Errorf("leader election needs a namespace, set --leader-elect-namespace or POD_NAMESPACE")
+	}
+	clientset, err := storeConfig.GetClientset()
+	if err != nil {
+		return nil, err
+	}
+	lock := &resourcelock.LeaseLock{
+		LeaseMeta: metav1.ObjectMeta{
+			Name:      k.LeaderElectLeaseName,
+			Namespace: k.LeaderElectNamespace,
+		},
+		Client: clientset.CoordinationV1(),
+		LockConfig: resourcelock.ResourceLockConfig{
+			Identity: k.LeaderElectIdentity,
+		},
+	}
+	state := &leaderState{}
+	leaderElectionConfig := leaderelection.LeaderElectionConfig{
+		Lock:            lock,
+		ReleaseOnCancel: true,
+		LeaseDuration:   k.LeaderElectLeaseDuration,
+		RenewDeadline:   k.LeaderElectLeaseDuration * 2 / 3,
+		RetryPeriod:     k.LeaderElectLeaseDuration / 5,
+		Callbacks: leaderelection.LeaderCallbacks{
+			OnStartedLeading: func(ctx context.Context) {
+				logrus.Infof("%s is now the leader", k.LeaderElectIdentity)
+				state.setLeader(true)
+			},
+			OnStoppedLeading: func() {
+				logrus.Infof("%s stopped leading", k.LeaderElectIdentity)
+				state.setLeader(false)
+			},
+			OnNewLeader: func(identity string) {
+				logrus.Infof("Current leader is %s", identity)
+			},
+		},
+	}
+	elector, err := leaderelection.NewLeaderElector(leaderElectionConfig)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		// Run returns when the leadership is lost, try to acquire it again
+		for ctx.Err() == nil {
+			elector.Run(ctx)
+		}
+	}()
+	return state.isLeader, nil
+}
@@ -3,8 +3,9 @@ package parse
 import (
 	"strings"
 
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/clusterconfig"
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
-	"github.com/sirupsen/logrus"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/util"
 )
 
 type UnmanagedFlagError string
@@ -13,29 +14,6 @@ func (u UnmanagedFlagError) Error() string {
 	return string(u)
 }
 
-func ParseFlagAndResources(cmdVerb string, cmdArgs []string) (resourceType resources.ResourceType, flagCompletion FlagCompletion, err error) {
-	resourceType = resources.ResourceTypeUnknown
-	flagCompletion = CheckFlagManaged(cmdArgs)
-	if flagCompletion == FlagUnmanaged {
-		logrus.Infof("Flag is unmanaged in %s, bailing out", cmdArgs)
-		err = UnmanagedFlagError(strings.Join(cmdArgs, " "))
-		return
-	}
-	logrus.Infof("Flag parsed: %s", flagCompletion.String())
-
-	if flagCompletion == FlagNamespace {
-		resourceType = resources.ResourceTypeNamespace
-		return
-	}
-	resourceType = resources.GetResourceType(cmdVerb, cmdArgs)
-
-	if resourceType == resources.ResourceTypeUnknown {
-		err = resources.UnknownResourceError{ResourceStr: strings.Join(cmdArgs, " ")}
-		return
-	}
-	return
-}
-
 func ParseNamespaceFromArgs(args []string) *string {
 	for k, arg := range args {
 		if (arg == "-n" || arg == "--namespace") && len(args) > k+1 && args[k+1] != " " {
@@ -47,3 +25,224 @@ func ParseNamespaceFromArgs(args []string) *string {
 	}
 	return nil
 }
+
+// globalValueFlags are the flags accepted by all verbs consuming the next argument
+var globalValueFlags = []string{"-n", "--namespace", "--context", "--kubeconfig", "--cluster", "--user"}
+
+// valueFlags are the flags consuming the next argument
+var valueFlags = append([]string{"-c", "--container", "-l", "--selector",
+	"--field-selector", "-f", "--filename", "-o", "--output", "--address"}, globalValueFlags...)
+
+// verbBoolFlags are the flags of a verb not taking a value while
+// other verbs use them as value flags, like -f which is --follow for logs
+var verbBoolFlags = map[string][]string{
+	"logs": {"-f"},
+}
+
+// isBoolFlag returns true if the flag takes no value for the verb
+func isBoolFlag(cmdVerb string, flag string) bool {
+	return util.IsStringIn(flag, verbBoolFlags[cmdVerb])
+}
+
+// isValueFlag returns true if the flag consumes the next argument for the verb
+func isValueFlag(cmdVerb string, flag string) bool {
+	return util.IsStringIn(flag, valueFlags) && !isBoolFlag(cmdVerb, flag)
+}
+
+// ParseConfigOverrides returns the kubeconfig flags provided in the arguments
+func ParseConfigOverrides(args []string) clusterconfig.ConfigOverrides {
+	overrides := clusterconfig.ConfigOverrides{}
+	flagValues := map[string]*string{
+		"--context":    &overrides.Context,
+		"--kubeconfig": &overrides.Kubeconfig,
+		"--cluster":    &overrides.Cluster,
+		"--user":       &overrides.User,
+	}
+	// The argument being completed is ignored
+	for i := 0; i < len(args)-1; i++ {
+		for flag, value := range flagValues {
+			if args[i] == flag && i+1 < len(args)-1 {
+				*value = args[i+1]
+			} else if strings.HasPrefix(args[i], flag+"=") {
+				*value = strings.TrimPrefix(args[i], flag+"=")
+			}
+		}
+	}
+	return overrides
+}
+
+// SplitVerb returns the kubectl verb and its arguments. Flags provided
+// before the verb, like kubectl --context prod get pods, are kept in
+// the arguments. The verb is empty if only flags are provided.
+func SplitVerb(args []string) (string, []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if strings.HasPrefix(arg, "-") {
+			// Only global flags can be provided before the verb
+			if util.IsStringIn(arg, globalValueFlags) {
+				i++
+			}
+			continue
+		}
+		verbArgs := append([]string{}, args[:i]...)
+		return arg, append(verbArgs, args[i+1:]...)
+	}
+	return "", args
+}
+
+// GetPositionalArgs returns the arguments of the verb which are neither flags nor flag values
+func GetPositionalArgs(cmdVerb string, args []string) []string {
+	res := []string{}
+	for i := 0; i < len(args); i++ {
+		arg := strings.TrimSpace(args[i])
+		if arg == "--" {
+			// Remaining arguments are the command to run
+			break
+		}
+		if strings.HasPrefix(arg, "-") {
+			if isValueFlag(cmdVerb, arg) {
+				i++
+			}
+			continue
+		}
+		if arg == "" {
+			continue
+		}
+		res = append(res, arg)
+	}
+	return res
+}
+
+// GetTypeNamePrefix returns the type prefix to use when the resources are
+// provided as type/name, like deploy/ for describe deploy/<TAB>. The type
+// of the argument being completed is used first, then the type of the last
+// type/name argument.
+func GetTypeNamePrefix(cmdVerb string, args []string) (string, bool) {
+	if len(args) == 0 {
+		return "", false
+	}
+	candidates := []string{args[len(args)-1]}
+	positionalArgs := GetPositionalArgs(cmdVerb, args[:len(args)-1])
+	for i := len(positionalArgs) - 1; i >= 0; i-- {
+		candidates = append(candidates, positionalArgs[i])
+	}
+	for _, arg := range candidates {
+		if _, _, ok := resources.ParseTypeName(arg); ok {
+			typeStr, _, _ := strings.Cut(arg, "/")
+			return typeStr + "/", true
+		}
+	}
+	return "", false
+}
+
+// ParsePodFromArgs returns the namespace and the pod targeted by exec, logs,
+// attach or cp. The namespace is nil if not provided.
+func ParsePodFromArgs(cmdVerb string, args []string) (*string, string) {
+	namespace := ParseNamespaceFromArgs(args)
+	for _, arg := range GetPositionalArgs(cmdVerb, args) {
+		if cmdVerb == "cp" {
+			// Remote file is specified as [namespace/]pod:path
+			idx := strings.Index(arg, ":")
+			if idx <= 0 {
+				continue
+			}
+			pod := arg[:idx]
+			if i := strings.Index(pod, "/"); i >= 0 {
+				podNamespace := pod[:i]
+				namespace = &podNamespace
+				pod = pod[i+1:]
+			}
+			return namespace, pod
+		}
+		return namespace, strings.TrimPrefix(arg, "pod/")
+	}
+	return namespace, ""
+}
+
+// parseTarget returns the resource targeted by the positional arguments
+// following the first skipArgs ones. The resource is provided as type/name or
+// as type name, assignments like container=image are ignored. Only the
+// allowed types are accepted, any type if allowedTypes is empty.
+func parseTarget(cmdVerb string, args []string, skipArgs int,
+	allowedTypes []resources.ResourceType) (resources.ResourceType, string, bool) {
+	targetArgs := []string{}
+	for _, arg := range GetPositionalArgs(cmdVerb, args) {
+		if !strings.Contains(arg, "=") {
+			targetArgs = append(targetArgs, arg)
+		}
+	}
+	if len(targetArgs) <= skipArgs {
+		return resources.ResourceTypeUnknown, "", false
+	}
+	targetArgs = targetArgs[skipArgs:]
+	resourceType, name, ok := resources.ParseTypeName(targetArgs[0])
+	if !ok {
+		resourceType = resources.ParseResourceType(targetArgs[0])
+		if len(targetArgs) > 1 {
+			name = targetArgs[1]
+		}
+	}
+	if resourceType == resources.ResourceTypeUnknown || resourceType == resources.ResourceTypeApiResource {
+		return resources.ResourceTypeUnknown, "", false
+	}
+	if len(allowedTypes) > 0 && !resources.IsResourceTypeIn(resourceType, allowedTypes) {
+		return resources.ResourceTypeUnknown, "", false
+	}
+	return resourceType, name, true
+}
+
+var setImageTypes = []resources.ResourceType{resources.ResourceTypeDeployment, resources.ResourceTypeStatefulSet,
+	resources.ResourceTypeReplicaSet, resources.ResourceTypeDaemonSet}
+
+// ParseSetImageTarget returns the workload type and name of a set image command.
+// The workload can be provided as type/name or as type name.
+func ParseSetImageTarget(args []string) (resources.ResourceType, string, bool) {
+	positionalArgs := GetPositionalArgs("set", args)
+	if len(positionalArgs) < 2 || positionalArgs[0] != "image" {
+		return resources.ResourceTypeUnknown, "", false
+	}
+	return parseTarget("set", args, 1, setImageTypes)
+}
+
+var dataKeyTypes = []resources.ResourceType{resources.ResourceTypeSecret, resources.ResourceTypeConfigMap}
+
+// ParseDataKeyTarget returns the secret or configMap targeted by a jsonpath
+// data expression. The resource can be provided as type/name or as type name.
+func ParseDataKeyTarget(args []string) (resources.ResourceType, string, bool) {
+	return parseTarget("get", args, 0, dataKeyTypes)
+}
+
+var portForwardTypes = []resources.ResourceType{resources.ResourceTypePod, resources.ResourceTypeService,
+	resources.ResourceTypeDeployment, resources.ResourceTypeStatefulSet,
+	resources.ResourceTypeReplicaSet, resources.ResourceTypeDaemonSet}
+
+// ParsePortForwardTarget returns the resource targeted by a port-forward
+// command. The target is provided as type/name, or as a pod name. The last
+// argument is the one being completed and is never considered as the target.
+func ParsePortForwardTarget(args []string) (resources.ResourceType, string, bool) {
+	if len(args) == 0 {
+		return resources.ResourceTypeUnknown, "", false
+	}
+	positionalArgs := GetPositionalArgs("port-forward", args[:len(args)-1])
+	if len(positionalArgs) == 0 {
+		return resources.ResourceTypeUnknown, "", false
+	}
+	if !strings.Contains(positionalArgs[0], "/") {
+		return resources.ResourceTypePod, positionalArgs[0], true
+	}
+	return parseTarget("port-forward", args[:len(args)-1], 0, portForwardTypes)
+}
+
+// ParseTagEditTarget returns the resource targeted by a label or annotate
+// command once its name is provided, as type/name or as type name. The last
+// argument is the one being completed and is never considered as the target.
+func ParseTagEditTarget(cmdVerb string, args []string) (resources.ResourceType, string, bool) {
+	if len(args) == 0 {
+		return resources.ResourceTypeUnknown, "", false
+	}
+	resourceType, name, ok := parseTarget(cmdVerb, args[:len(args)-1], 0, nil)
+	if !ok || name == "" {
+		return resources.ResourceTypeUnknown, "", false
+	}
+	return resourceType, name, true
+}
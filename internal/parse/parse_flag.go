@@ -12,20 +12,26 @@ const (
 	FlagLabel FlagCompletion = iota
 	FlagFieldSelector
 	FlagNamespace
+	FlagContainer
+	FlagDataKey
+	FlagContext
 	FlagNone
 	FlagUnmanaged
 )
 
 func (f FlagCompletion) String() string {
-	flagStr := [...]string{"Label", "FieldSelector", "Namespace", "None", "Unmanaged"}
+	flagStr := [...]string{"Label", "FieldSelector", "Namespace", "Container", "DataKey", "Context", "None", "Unmanaged"}
 	if len(flagStr) < int(f) {
 		return "Unknown"
 	}
 	return flagStr[f]
 }
 
-func parsePreviousFlag(s string) FlagCompletion {
+func parsePreviousFlag(cmdVerb string, s string) FlagCompletion {
 	logrus.Debugf("Parsing previous flag '%s'", s)
+	if isBoolFlag(cmdVerb, s) {
+		return FlagNone
+	}
 	switch s {
 	case "-l":
 		return FlagLabel
@@ -37,6 +43,12 @@ func parsePreviousFlag(s string) FlagCompletion {
 		fallthrough
 	case "--namespace":
 		return FlagNamespace
+	case "-c":
+		fallthrough
+	case "--container":
+		return FlagContainer
+	case "--context":
+		return FlagContext
 
 	case "--filename":
 		fallthrough
@@ -67,11 +79,40 @@ func parseLastFlag(s string) FlagCompletion {
 		return FlagNamespace
 	case "--field-selector=":
 		return FlagFieldSelector
+	case "-c":
+		fallthrough
+	case "-c=":
+		fallthrough
+	case "--container=":
+		return FlagContainer
+	case "--context=":
+		return FlagContext
 	}
 	return FlagUnmanaged
 }
 
-func CheckFlagManaged(args []string) FlagCompletion {
+// dataKeyPrefixes are the jsonpath expressions followed by a secret or configMap key
+var dataKeyPrefixes = []string{"{.data.", "{.stringData."}
+
+// ParseDataKeyPrefix returns the part of the argument preceding the data key
+// when the argument ends inside a jsonpath data expression, like in
+// jsonpath='{.data.
+func ParseDataKeyPrefix(arg string) (string, bool) {
+	for _, dataKeyPrefix := range dataKeyPrefixes {
+		idx := strings.LastIndex(arg, dataKeyPrefix)
+		if idx < 0 {
+			continue
+		}
+		prefix := arg[:idx+len(dataKeyPrefix)]
+		if strings.Contains(arg[len(prefix):], "}") {
+			continue
+		}
+		return prefix, true
+	}
+	return "", false
+}
+
+func CheckFlagManaged(cmdVerb string, args []string) FlagCompletion {
 	logrus.Infof("Checking Managed Flag '%s'", args)
 	if len(args) == 0 {
 		return FlagNone
@@ -82,13 +123,16 @@ func CheckFlagManaged(args []string) FlagCompletion {
 		}
 	}
 	lastArg := args[len(args)-1]
+	if _, ok := ParseDataKeyPrefix(lastArg); ok {
+		return FlagDataKey
+	}
 	if strings.HasPrefix(lastArg, "-") {
 		return parseLastFlag(lastArg)
 	}
 	if len(args) >= 2 {
 		penultimateArg := args[len(args)-2]
 		if strings.HasPrefix(penultimateArg, "-") {
-			return parsePreviousFlag(penultimateArg)
+			return parsePreviousFlag(cmdVerb, penultimateArg)
 		}
 	}
 	return FlagNone
@@ -3,6 +3,8 @@ package parse
 import (
 	"testing"
 
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/clusterconfig"
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
 	"github.com/stretchr/testify/require"
 )
 
@@ -14,33 +16,209 @@ func TestUnmanagedArgs(t *testing.T) {
 		{"--selector"},
 	}
 	for _, args := range cmdArgs {
-		r := CheckFlagManaged(args)
+		r := CheckFlagManaged("get", args)
 		require.Equal(t, FlagUnmanaged.String(), r.String())
 	}
 }
 
 type flagTest struct {
+	verb   string
 	flag   []string
 	result FlagCompletion
 }
 
 func TestManagedArgs(t *testing.T) {
 	cmdArgs := []flagTest{
-		{[]string{"--selector="}, FlagLabel},
-		{[]string{"--field-selector", ""}, FlagFieldSelector},
-		{[]string{"--field-selector="}, FlagFieldSelector},
-		{[]string{"--all-namespaces", ""}, FlagNone},
-		{[]string{"-t", ""}, FlagNone},
-		{[]string{"-i", ""}, FlagNone},
-		{[]string{"-ti", ""}, FlagNone},
-		{[]string{"-it", ""}, FlagNone},
-		{[]string{"-n"}, FlagNamespace},
-		{[]string{"-n="}, FlagNamespace},
-		{[]string{"-n", " "}, FlagNamespace},
-		{[]string{"--namespace", ""}, FlagNamespace},
+		{"get", []string{"--selector="}, FlagLabel},
+		{"get", []string{"--field-selector", ""}, FlagFieldSelector},
+		{"get", []string{"--field-selector="}, FlagFieldSelector},
+		{"get", []string{"--all-namespaces", ""}, FlagNone},
+		{"get", []string{"-t", ""}, FlagNone},
+		{"get", []string{"-i", ""}, FlagNone},
+		{"get", []string{"-ti", ""}, FlagNone},
+		{"get", []string{"-it", ""}, FlagNone},
+		{"get", []string{"-n"}, FlagNamespace},
+		{"get", []string{"-n="}, FlagNamespace},
+		{"get", []string{"-n", " "}, FlagNamespace},
+		{"get", []string{"--namespace", ""}, FlagNamespace},
+		{"get", []string{"-c"}, FlagContainer},
+		{"get", []string{"mypod", "-c", " "}, FlagContainer},
+		{"get", []string{"--container="}, FlagContainer},
+		{"get", []string{"secret", "mysecret", "-o", "jsonpath='{.data."}, FlagDataKey},
+		{"get", []string{"cm/myconfig", "-o=jsonpath={.data.conf"}, FlagDataKey},
+		{"get", []string{"secret", "mysecret", "-o", "jsonpath='{.data.password}'"}, FlagUnmanaged},
+		{"apply", []string{"-f", " "}, FlagUnmanaged},
+		{"logs", []string{"-f", " "}, FlagNone},
+		{"logs", []string{"-f", "mypod", "-c", " "}, FlagContainer},
 	}
 	for _, args := range cmdArgs {
-		r := CheckFlagManaged(args.flag)
+		r := CheckFlagManaged(args.verb, args.flag)
 		require.Equal(t, args.result.String(), r.String())
 	}
 }
+
+func TestParsePodFromArgs(t *testing.T) {
+	testDatas := []struct {
+		verb              string
+		args              []string
+		expectedNamespace string
+		expectedPod       string
+	}{
+		{"exec", []string{"-ti", "mypod", "-c", " "}, "", "mypod"},
+		{"exec", []string{"-n", "kube-system", "-ti", "pod/mypod", "-c"}, "kube-system", "mypod"},
+		{"logs", []string{"--context", "minikube", "-c", " "}, "", ""},
+		{"logs", []string{"-f", "mypod", "-c", " "}, "", "mypod"},
+		{"cp", []string{"/tmp/foo", "kube-system/mypod:/tmp/bar", "-c"}, "kube-system", "mypod"},
+		{"cp", []string{"mypod:/tmp/bar", "/tmp/foo", "--container="}, "", "mypod"},
+	}
+	for _, testData := range testDatas {
+		namespace, pod := ParsePodFromArgs(testData.verb, testData.args)
+		require.Equal(t, testData.expectedPod, pod, "args: %s", testData.args)
+		if testData.expectedNamespace == "" {
+			require.Nil(t, namespace)
+		} else {
+			require.Equal(t, testData.expectedNamespace, *namespace)
+		}
+	}
+}
+
+func TestParseSetImageTarget(t *testing.T) {
+	testDatas := []struct {
+		args                 []string
+		expectedResourceType resources.ResourceType
+		expectedName         string
+		expectedOk           bool
+	}{
+		{[]string{"image", "deploy/web", " "}, resources.ResourceTypeDeployment, "web", true},
+		{[]string{"image", "statefulset.apps/db", " "}, resources.ResourceTypeStatefulSet, "db", true},
+		{[]string{"image", "-n", "prod", "ds", "agent", " "}, resources.ResourceTypeDaemonSet, "agent", true},
+		{[]string{"image", "deploy/web", "web=nginx:1.21", " "}, resources.ResourceTypeDeployment, "web", true},
+		{[]string{"image", "pods/web", " "}, resources.ResourceTypeUnknown, "", false},
+		{[]string{"env", "deploy/web", " "}, resources.ResourceTypeUnknown, "", false},
+	}
+	for _, testData := range testDatas {
+		resourceType, name, ok := ParseSetImageTarget(testData.args)
+		require.Equal(t, testData.expectedOk, ok, "args: %s", testData.args)
+		require.Equal(t, testData.expectedResourceType, resourceType, "args: %s", testData.args)
+		require.Equal(t, testData.expectedName, name, "args: %s", testData.args)
+	}
+}
+
+func TestParseDataKeyTarget(t *testing.T) {
+	testDatas := []struct {
+		args                 []string
+		expectedResourceType resources.ResourceType
+		expectedName         string
+		expectedOk           bool
+	}{
+		{[]string{"secret", "mysecret", "-o", "jsonpath='{.data."}, resources.ResourceTypeSecret, "mysecret", true},
+		{[]string{"-n", "prod", "cm/myconfig", "-o=jsonpath={.data."}, resources.ResourceTypeConfigMap, "myconfig", true},
+		{[]string{"secrets", "-o", "jsonpath={.data."}, resources.ResourceTypeSecret, "", true},
+		{[]string{"pods", "mypod", "-o", "jsonpath={.data."}, resources.ResourceTypeUnknown, "", false},
+	}
+	for _, testData := range testDatas {
+		resourceType, name, ok := ParseDataKeyTarget(testData.args)
+		require.Equal(t, testData.expectedOk, ok, "args: %s", testData.args)
+		require.Equal(t, testData.expectedResourceType, resourceType, "args: %s", testData.args)
+		require.Equal(t, testData.expectedName, name, "args: %s", testData.args)
+	}
+}
+
+func TestParsePortForwardTarget(t *testing.T) {
+	testDatas := []struct {
+		args                 []string
+		expectedResourceType resources.ResourceType
+		expectedName         string
+		expectedOk           bool
+	}{
+		{[]string{" "}, resources.ResourceTypeUnknown, "", false},
+		{[]string{"svc/we"}, resources.ResourceTypeUnknown, "", false},
+		{[]string{"svc/web", " "}, resources.ResourceTypeService, "web", true},
+		{[]string{"-n", "prod", "deployment.apps/web", "80"}, resources.ResourceTypeDeployment, "web", true},
+		{[]string{"--address", "0.0.0.0", "mypod", " "}, resources.ResourceTypePod, "mypod", true},
+		{[]string{"cm/web", " "}, resources.ResourceTypeUnknown, "", false},
+	}
+	for _, testData := range testDatas {
+		resourceType, name, ok := ParsePortForwardTarget(testData.args)
+		require.Equal(t, testData.expectedOk, ok, "args: %s", testData.args)
+		require.Equal(t, testData.expectedResourceType, resourceType, "args: %s", testData.args)
+		require.Equal(t, testData.expectedName, name, "args: %s", testData.args)
+	}
+}
+
+func TestParseTagEditTarget(t *testing.T) {
+	testDatas := []struct {
+		args                 []string
+		expectedResourceType resources.ResourceType
+		expectedName         string
+		expectedOk           bool
+	}{
+		{[]string{"deploy", " "}, resources.ResourceTypeUnknown, "", false},
+		{[]string{"deploy", "cored"}, resources.ResourceTypeUnknown, "", false},
+		{[]string{"deploy", "coredns", " "}, resources.ResourceTypeDeployment, "coredns", true},
+		{[]string{"-n", "kube-system", "deployment.apps/coredns", "team="}, resources.ResourceTypeDeployment, "coredns", true},
+		{[]string{"pods", "mypod", "team=a", " "}, resources.ResourceTypePod, "mypod", true},
+		{[]string{"unknown", "x", " "}, resources.ResourceTypeUnknown, "", false},
+		{[]string{"po.backup", " "}, resources.ResourceTypeUnknown, "", false},
+		{[]string{"pods", "po.backup", " "}, resources.ResourceTypePod, "po.backup", true},
+	}
+	for _, testData := range testDatas {
+		resourceType, name, ok := ParseTagEditTarget("label", testData.args)
+		require.Equal(t, testData.expectedOk, ok, "args: %s", testData.args)
+		require.Equal(t, testData.expectedResourceType, resourceType, "args: %s", testData.args)
+		require.Equal(t, testData.expectedName, name, "args: %s", testData.args)
+	}
+}
+
+func TestGetTypeNamePrefix(t *testing.T) {
+	testDatas := []struct {
+		args   []string
+		prefix string
+		ok     bool
+	}{
+		{[]string{"deploy/"}, "deploy/", true},
+		{[]string{"deployment/co"}, "deployment/", true},
+		{[]string{"pod/a", " "}, "pod/", true},
+		{[]string{"pod/a", "-n", "svc/b", " "}, "pod/", true},
+		{[]string{"pods", " "}, "", false},
+		{[]string{"pods,svc", " "}, "", false},
+	}
+	for _, testData := range testDatas {
+		prefix, ok := GetTypeNamePrefix("get", testData.args)
+		require.Equal(t, testData.ok, ok, "args: %s", testData.args)
+		require.Equal(t, testData.prefix, prefix, "args: %s", testData.args)
+	}
+}
+
+func TestParseConfigOverrides(t *testing.T) {
+	overrides := ParseConfigOverrides([]string{"--context", "prod", "--kubeconfig=/tmp/config", "pods", " "})
+	require.Equal(t, clusterconfig.ConfigOverrides{Context: "prod", Kubeconfig: "/tmp/config"}, overrides)
+
+	overrides = ParseConfigOverrides([]string{"pods", "--cluster", "c1", "--user=u1", " "})
+	require.Equal(t, clusterconfig.ConfigOverrides{Cluster: "c1", User: "u1"}, overrides)
+
+	// The flag being completed is ignored
+	overrides = ParseConfigOverrides([]string{"pods", "--context", " "})
+	require.Equal(t, clusterconfig.ConfigOverrides{}, overrides)
+	overrides = ParseConfigOverrides([]string{"pods", "--context=pr"})
+	require.Equal(t, clusterconfig.ConfigOverrides{}, overrides)
+}
+
+func TestSplitVerb(t *testing.T) {
+	testDatas := []struct {
+		args         []string
+		expectedVerb string
+		expectedArgs []string
+	}{
+		{[]string{"get", "pods", " "}, "get", []string{"pods", " "}},
+		{[]string{"--context", "prod", "get", "pods", " "}, "get", []string{"--context", "prod", "pods", " "}},
+		{[]string{"--context=prod", "-n", "default", "exec", " "}, "exec", []string{"--context=prod", "-n", "default", " "}},
+		{[]string{"--context", " "}, "", []string{"--context", " "}},
+		{[]string{"logs", "-f", "mypod", "-c", " "}, "logs", []string{"-f", "mypod", "-c", " "}},
+	}
+	for _, testData := range testDatas {
+		verb, args := SplitVerb(testData.args)
+		require.Equal(t, testData.expectedVerb, verb, "args: %s", testData.args)
+		require.Equal(t, testData.expectedArgs, args, "args: %s", testData.args)
+	}
+}
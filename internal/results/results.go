@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/bonnefoa/kubectl-fzf/v3/internal/completion"
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/fetcher"
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/k8s/resources"
 	"github.com/bonnefoa/kubectl-fzf/v3/internal/parse"
@@ -23,6 +24,9 @@ func ProcessResult(cmdUse string, cmdArgs []string,
 	if err != nil {
 		return "", err
 	}
+	if fzfLines := strings.Split(fzfResult, "\n"); len(fzfLines) > 1 {
+		return processMultiResult(cmdUse, cmdArgs, fzfLines, namespace)
+	}
 	return processResultWithNamespace(cmdUse, cmdArgs, fzfResult, namespace)
 }
 
@@ -47,28 +51,67 @@ func processResultWithNamespace(cmdUse string, cmdArgs []string, fzfResult strin
 		return "", fmt.Errorf("fzf result should have at least 3 elements, got %v", resultFields)
 	}
 	logrus.Debugf("Processing fzfResult '%s', cmdArgs '%s', current namespace '%s'", fzfResult, cmdArgs, currentNamespace)
-	resourceType, flagCompletion, err := parse.ParseFlagAndResources(cmdUse, cmdArgs)
+	if completion.IsContextCompletion(cmdUse, cmdArgs) {
+		// 0 -> context, 1 -> cluster
+		if lastWord := cmdArgs[len(cmdArgs)-1]; lastWord == "--context=" {
+			return lastWord + resultFields[0], nil
+		}
+		return resultFields[0], nil
+	}
+	if completion.IsSubVerbCompletion(cmdUse, cmdArgs) {
+		// 0 -> subcommand, 1 -> resources
+		return resultFields[0], nil
+	}
+	if _, _, ok := parse.ParseSetImageTarget(cmdArgs); cmdUse == "set" && ok {
+		// 0 -> namespace, 1 -> name, 2 -> container
+		if len(resultFields) < 3 {
+			return "", fmt.Errorf("container result should have at least 3 elements, got %v", resultFields)
+		}
+		return fmt.Sprintf("%s=", resultFields[2]), nil
+	}
+	if cmdUse == "port-forward" && parse.CheckFlagManaged(cmdUse, cmdArgs) == parse.FlagNone {
+		return processPortForwardResult(cmdArgs, resultFields, currentNamespace)
+	}
+	if isTagEdit(cmdUse, cmdArgs) {
+		return processTagEditResult(cmdArgs, resultFields), nil
+	}
+	resourceType, flagCompletion, err := completion.ParseFlagAndResources(cmdUse, cmdArgs)
 	if err != nil {
 		return "", err
 	}
 	logrus.Debugf("Resource type %s, flagCompletion %s", resourceType, flagCompletion)
 
+	lastWord := cmdArgs[len(cmdArgs)-1]
 	if resourceType == resources.ResourceTypeApiResource {
+		if resources.IsResourceTypeListPrefix(lastWord) {
+			// Keep the resource types already provided
+			return lastWord[:strings.LastIndex(lastWord, ",")+1] + resultFields[0], nil
+		}
 		return resultFields[0], nil
 	}
 
-	// Generic resource
-	resultNamespace := resultFields[0]
-	resultValue := resultFields[1]
-	if !resourceType.IsNamespaced() {
-		resultValue = resultFields[0]
-		resultNamespace = ""
+	if flagCompletion == parse.FlagDataKey {
+		// 0 -> namespace, 1 -> name, 2 -> key
+		if len(resultFields) < 3 {
+			return "", fmt.Errorf("data key result should have at least 3 elements, got %v", resultFields)
+		}
+		return completeDataKeyExpression(lastWord, resultFields[2]), nil
 	}
-
-	if resourceType == resources.ResourceTypeNamespace {
-		resultValue = resultFields[0]
+	if flagCompletion == parse.FlagContainer {
+		// 0 -> namespace, 1 -> pod, 2 -> container
+		if len(resultFields) < 3 {
+			return "", fmt.Errorf("container result should have at least 3 elements, got %v", resultFields)
+		}
+		if util.IsStringIn(lastWord, []string{"-c", "-c=", "--container="}) {
+			return fmt.Sprintf("%s%s", lastWord, resultFields[2]), nil
+		}
+		return resultFields[2], nil
 	}
 
+	resultValue, resultNamespace, err := getResourceResult(cmdUse, cmdArgs, resourceType, flagCompletion, resultFields)
+	if err != nil {
+		return "", err
+	}
 	logrus.Debugf("Result namespace: %s, resultValue: %s", resultNamespace, resultValue)
 
 	var cmdNamespace *string
@@ -79,13 +122,21 @@ func processResultWithNamespace(cmdUse string, cmdArgs []string, fzfResult strin
 		}
 		logrus.Debugf("Namespace parsed: %s", *cmdNamespace)
 	}
-	lastWord := cmdArgs[len(cmdArgs)-1]
 	// add flag to the completion
 	lastFlags := []string{"-l=", "-l", "--field-selector=", "--selector=", "-n=", "--namespace=", "-n"}
 	if util.IsStringIn(lastWord, lastFlags) {
 		resultValue = fmt.Sprintf("%s%s", lastWord, resultValue)
 	}
 
+	if cmdUse == "cp" {
+		// Remote path is provided as [namespace/]pod:path
+		if (cmdNamespace != nil && *cmdNamespace == resultNamespace) ||
+			((cmdNamespace == nil || *cmdNamespace == "") && resultNamespace == currentNamespace) {
+			return fmt.Sprintf("%s:", resultValue), nil
+		}
+		return fmt.Sprintf("%s/%s:", resultNamespace, resultValue), nil
+	}
+
 	if cmdNamespace != nil && *cmdNamespace == resultNamespace {
 		return resultValue, nil
 	}
@@ -96,3 +147,157 @@ func processResultWithNamespace(cmdUse string, cmdArgs []string, fzfResult strin
 	}
 	return resultValue, nil
 }
+
+// getResourceResult returns the name and the namespace of the selected
+// resource. The namespace is empty for namespaceless resources.
+func getResourceResult(cmdUse string, cmdArgs []string, resourceType resources.ResourceType,
+	flagCompletion parse.FlagCompletion, resultFields []string) (string, string, error) {
+	typeNamePrefix, isTypeName := parse.GetTypeNamePrefix(cmdUse, cmdArgs)
+	if flagCompletion != parse.FlagNone {
+		isTypeName = false
+	}
+	// Generic resource
+	resultNamespace := resultFields[0]
+	resultValue := resultFields[1]
+	if resourceTypes := completion.GetResourceTypes(cmdUse, cmdArgs); len(resourceTypes) > 1 {
+		// 0 -> type, 1 -> namespace, 2 -> name
+		if len(resultFields) < 3 {
+			return "", "", fmt.Errorf("multi resource result should have at least 3 elements, got %v", resultFields)
+		}
+		typeNamePrefix = resultFields[0] + "/"
+		resultNamespace = resultFields[1]
+		resultValue = resultFields[2]
+		if resultNamespace == "None" {
+			resultNamespace = ""
+		}
+	} else if !resourceType.IsNamespaced() {
+		resultValue = resultFields[0]
+		resultNamespace = ""
+	}
+
+	if resourceType == resources.ResourceTypeNamespace {
+		resultValue = resultFields[0]
+	}
+	if isTypeName {
+		resultValue = typeNamePrefix + resultValue
+	}
+	return resultValue, resultNamespace, nil
+}
+
+// processMultiResult joins the resources selected in fzf. The selected
+// resources need to be in a single namespace as kubectl only accepts one -n.
+func processMultiResult(cmdUse string, cmdArgs []string, fzfLines []string, currentNamespace string) (string, error) {
+	resourceType, flagCompletion, err := completion.ParseFlagAndResources(cmdUse, cmdArgs)
+	if err != nil {
+		return "", err
+	}
+	values := []string{}
+	namespaces := []string{}
+	for _, line := range fzfLines {
+		resultFields := strings.Fields(line)
+		if len(resultFields) < 2 {
+			return "", fmt.Errorf("fzf result should have at least 2 elements, got %v", resultFields)
+		}
+		resultValue, resultNamespace, err := getResourceResult(cmdUse, cmdArgs, resourceType, flagCompletion, resultFields)
+		if err != nil {
+			return "", err
+		}
+		values = append(values, resultValue)
+		if resultNamespace != "" && !util.IsStringIn(resultNamespace, namespaces) {
+			namespaces = append(namespaces, resultNamespace)
+		}
+	}
+	res := strings.Join(values, " ")
+	if len(namespaces) == 0 {
+		return res, nil
+	}
+	if len(namespaces) > 1 {
+		return "", fmt.Errorf("selected resources are in multiple namespaces (%s), %s only accepts a single namespace: select resources of one namespace",
+			strings.Join(namespaces, ", "), cmdUse)
+	}
+	cmdNamespace, err := parseNamespaceFlag(cmdArgs)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error parsing commands %s", cmdArgs)
+	}
+	if *cmdNamespace != "" && *cmdNamespace != namespaces[0] {
+		return "", fmt.Errorf("selected resources are in namespace %s while namespace %s is provided", namespaces[0], *cmdNamespace)
+	}
+	if *cmdNamespace == "" && namespaces[0] != currentNamespace {
+		return fmt.Sprintf("%s -n %s", res, namespaces[0]), nil
+	}
+	return res, nil
+}
+
+func processPortForwardResult(cmdArgs []string, resultFields []string, currentNamespace string) (string, error) {
+	if _, _, ok := parse.ParsePortForwardTarget(cmdArgs); ok {
+		// 0 -> namespace, 1 -> target, 2 -> local:remote port
+		if len(resultFields) < 3 {
+			return "", fmt.Errorf("port result should have at least 3 elements, got %v", resultFields)
+		}
+		return resultFields[2], nil
+	}
+	// 0 -> namespace, 1 -> target
+	resultNamespace := resultFields[0]
+	resultValue := resultFields[1]
+	cmdNamespace, err := parseNamespaceFlag(cmdArgs)
+	if err != nil {
+		return "", errors.Wrapf(err, "Error parsing commands %s", cmdArgs)
+	}
+	if *cmdNamespace == resultNamespace {
+		return resultValue, nil
+	}
+	if resultNamespace != currentNamespace {
+		return fmt.Sprintf("%s -n %s", resultValue, resultNamespace), nil
+	}
+	return resultValue, nil
+}
+
+// tagEditVerbs are the verbs editing the labels or annotations of a resource
+var tagEditVerbs = []string{"annotate", "label", "taint"}
+
+func isTagEdit(cmdUse string, cmdArgs []string) bool {
+	if !util.IsStringIn(cmdUse, tagEditVerbs) || parse.CheckFlagManaged(cmdUse, cmdArgs) != parse.FlagNone {
+		return false
+	}
+	_, _, ok := parse.ParseTagEditTarget(cmdUse, cmdArgs)
+	return ok
+}
+
+func processTagEditResult(cmdArgs []string, resultFields []string) string {
+	// 0 -> edit, 1 -> action
+	edit := resultFields[0]
+	if resultFields[1] == resources.TagEditOverwrite && !util.IsStringIn("--overwrite", cmdArgs) {
+		// Keep the edit last so the value can be typed directly
+		return fmt.Sprintf("--overwrite %s", edit)
+	}
+	return edit
+}
+
+// completeDataKeyExpression replaces the partial key of the jsonpath
+// expression with the selected key and closes the expression
+func completeDataKeyExpression(lastWord string, key string) string {
+	prefix, _ := parse.ParseDataKeyPrefix(lastWord)
+	// Dots in key names need to be escaped in jsonpath
+	res := fmt.Sprintf("%s%s}", prefix, strings.ReplaceAll(key, ".", "\\."))
+	for _, quote := range []string{"'", "\""} {
+		if strings.Count(prefix, quote)%2 == 1 {
+			res += quote
+		}
+	}
+	return res
+}
+
+// ProcessContainerPickResult adds the container picked in fzf to the pod completion
+func ProcessContainerPickResult(cmdUse string, podCompletion string, fzfResult string) (string, error) {
+	// 0 -> namespace, 1 -> pod, 2 -> container
+	resultFields := strings.Fields(fzfResult)
+	if len(resultFields) < 3 {
+		return "", fmt.Errorf("container result should have at least 3 elements, got %v", resultFields)
+	}
+	container := resultFields[2]
+	if cmdUse == "cp" {
+		// The pod completion ends with the remote path
+		return fmt.Sprintf("-c %s %s", container, podCompletion), nil
+	}
+	return fmt.Sprintf("%s -c %s", podCompletion, container), nil
+}
@@ -58,6 +58,64 @@ func TestResult(t *testing.T) {
 		{"kube-system spec.nodeName=minikube", "get", []string{"pods", "--field-selector", " "}, "default", "spec.nodeName=minikube -n kube-system"},
 		{"kube-system coredns-64897985d-nrblm", "get", []string{"pods", "c"}, "default", "coredns-64897985d-nrblm -n kube-system"},
 		{"apiservices.apiregistration.k8s.io None apiregistration.k8s.io/v1", "get", []string{" "}, "default", "apiservices.apiregistration.k8s.io"},
+		// Rbac
+		{"kube-system system:controller:bootstrap-signer Role/system:controller:bootstrap-signer ServiceAccount/kube-system/bootstrap-signer", "describe", []string{"rolebinding", " "}, "default", "system:controller:bootstrap-signer -n kube-system"},
+		{"cluster-admin 2 None 30d kubernetes.io/bootstrapping=rbac-defaults", "describe", []string{"clusterroles", " "}, "default", "cluster-admin"},
+		{"cluster-admin ClusterRole/cluster-admin Group/system:masters 30d", "get", []string{"clusterrolebinding", " "}, "default", "cluster-admin"},
+		// Cluster scoped infrastructure
+		{"standard k8s.io/minikube-hostpath Delete Immediate false true 30d", "describe", []string{"sc", " "}, "kube-system", "standard"},
+		{"csi-4b5d2 ebs.csi.aws.com ip-10-0-0-1 pvc-1234 true 2d", "get", []string{"volumeattachments", " "}, "default", "csi-4b5d2"},
+		// Container
+		{"kube-system coredns-6d4b75cb6d-m6m4q debugger ephemeral busybox:1.35 Running 0", "exec", []string{"-ti", "coredns-6d4b75cb6d-m6m4q", "-c", " "}, "default", "debugger"},
+		{"kube-system coredns-6d4b75cb6d-m6m4q coredns container k8s.gcr.io/coredns/coredns:v1.8.6 Running 0", "logs", []string{"coredns-6d4b75cb6d-m6m4q", "-c"}, "default", "-ccoredns"},
+		{"kube-system coredns-6d4b75cb6d-m6m4q coredns container k8s.gcr.io/coredns/coredns:v1.8.6 Running 0", "logs", []string{"coredns-6d4b75cb6d-m6m4q", "--container="}, "default", "--container=coredns"},
+		// Copy
+		{"kube-system coredns-6d4b75cb6d-m6m4q", "cp", []string{" "}, "default", "kube-system/coredns-6d4b75cb6d-m6m4q:"},
+		{"kube-system coredns-6d4b75cb6d-m6m4q", "cp", []string{"/tmp/file", " "}, "kube-system", "coredns-6d4b75cb6d-m6m4q:"},
+		{"kube-system coredns-6d4b75cb6d-m6m4q", "cp", []string{"-n", "kube-system", " "}, "default", "coredns-6d4b75cb6d-m6m4q:"},
+		{"kube-system coredns-6d4b75cb6d-m6m4q", "attach", []string{"-ti", " "}, "default", "coredns-6d4b75cb6d-m6m4q -n kube-system"},
+		// Set image
+		{"kube-system coredns coredns k8s.gcr.io/coredns/coredns:v1.8.6", "set", []string{"image", "deploy/coredns", " "}, "default", "coredns="},
+		// Data keys
+		{"kube-system coredns Corefile", "get", []string{"cm", "coredns", "-o", "jsonpath='{.data.Co"}, "default", "jsonpath='{.data.Corefile}'"},
+		{"default tls-cert tls.crt", "get", []string{"secret/tls-cert", "-o=jsonpath={.data."}, "default", "-o=jsonpath={.data.tls\\.crt}"},
+		// Port forward
+		{"kube-system svc/kube-dns dns-tcp:53,metrics:9153", "port-forward", []string{" "}, "default", "svc/kube-dns -n kube-system"},
+		{"kube-system svc/kube-dns dns-tcp:53,metrics:9153", "port-forward", []string{"-n", "kube-system", "svc/k"}, "default", "svc/kube-dns"},
+		{"kube-system service/kube-dns 8053:dns-tcp dns-tcp 53 TCP 53", "port-forward", []string{"svc/kube-dns", "-n", "kube-system", " "}, "default", "8053:dns-tcp"},
+		// Annotate
+		{"deployment.kubernetes.io/revision= overwrite 1", "annotate", []string{"deploy", "coredns", "-n", "kube-system", " "}, "default", "--overwrite deployment.kubernetes.io/revision="},
+		{"deployment.kubernetes.io/revision= overwrite 1", "annotate", []string{"deploy", "coredns", "--overwrite", " "}, "default", "deployment.kubernetes.io/revision="},
+		{"deployment.kubernetes.io/revision- remove 1", "annotate", []string{"deploy/coredns", "d"}, "default", "deployment.kubernetes.io/revision-"},
+		{"kube-system coredns 1 1 1 1", "annotate", []string{"deploy", " "}, "default", "coredns -n kube-system"},
+		// Label
+		{"k8s-app=kube-dns overwrite 2", "label", []string{"deploy", "coredns", " "}, "default", "--overwrite k8s-app=kube-dns"},
+		{"k8s-app- remove 2", "label", []string{"deploy", "coredns", " "}, "default", "k8s-app-"},
+		{"tier= add 4", "label", []string{"pods", "coredns-6d4b75cb6d-m6m4q", " "}, "default", "tier="},
+		// Taint
+		{"minikube 30d", "taint", []string{"nodes", " "}, "default", "minikube"},
+		{"dedicated:NoSchedule- remove 1 node", "taint", []string{"nodes", "minikube", " "}, "default", "dedicated:NoSchedule-"},
+		{"node-role.kubernetes.io/master:NoSchedule add 1 tolerations", "taint", []string{"nodes", "minikube", " "}, "default", "node-role.kubernetes.io/master:NoSchedule"},
+		// Type/name
+		{"kube-system coredns 1 1 1 1", "describe", []string{"deploy/"}, "default", "deploy/coredns -n kube-system"},
+		{"kube-system coredns 1 1 1 1", "logs", []string{"deployment/co"}, "kube-system", "deployment/coredns"},
+		{"kube-system coredns-64897985d-nrblm", "get", []string{"pod/a", " "}, "kube-system", "pod/coredns-64897985d-nrblm"},
+		{"minikube Ready", "get", []string{"node/"}, "default", "node/minikube"},
+		{"pods kube-system coredns-64897985d-nrblm", "get", []string{"pod/a", "svc/b", " "}, "kube-system", "pods/coredns-64897985d-nrblm"},
+		// Resource type lists
+		{"pods kube-system coredns-64897985d-nrblm", "get", []string{"pods,svc", " "}, "default", "coredns-64897985d-nrblm -n kube-system"},
+		{"services kube-system kube-dns", "get", []string{"pods,svc", " "}, "kube-system", "kube-dns"},
+		{"services svc v1 true Service", "get", []string{"pods,se"}, "default", "pods,services"},
+		// Contexts
+		{"prod-eu prod-eu admin payments false false", "config", []string{"use-context", " "}, "default", "prod-eu"},
+		{"prod-eu prod-eu admin payments false false", "get", []string{"pods", "--context="}, "default", "--context=prod-eu"},
+		{"default 30d kubernetes.io/metadata.name=default", "config", []string{"set-context", "--current", "--namespace", " "}, "kube-system", "default"},
+		// Subcommands
+		{"restart deployments,daemonsets,statefulsets", "rollout", []string{" "}, "default", "restart"},
+		{"deployments deploy apps/v1 true Deployment", "rollout", []string{"restart", " "}, "default", "deployments"},
+		{"kube-system coredns 1 1 1 1", "rollout", []string{"restart", "deploy", " "}, "default", "coredns -n kube-system"},
+		// Events
+		{"kube-system coredns-6d4b75cb6d-m6m4q.1 Pod/coredns-6d4b75cb6d-m6m4q Unhealthy Warning 1 5m Readiness_probe_failed", "describe", []string{"events", " "}, "default", "coredns-6d4b75cb6d-m6m4q.1 -n kube-system"},
 	}
 	for _, testData := range testDatas {
 		res, err := processResultWithNamespace(testData.cmdUse, testData.cmdArgs, testData.fzfResult, testData.currentNamespace)
@@ -67,3 +125,44 @@ func TestResult(t *testing.T) {
 			testData.cmdArgs, testData.currentNamespace, res)
 	}
 }
+
+func TestContainerPickResult(t *testing.T) {
+	containerResult := "kube-system coredns-6d4b75cb6d-m6m4q debugger ephemeral busybox:1.35 Running 0"
+	res, err := ProcessContainerPickResult("exec", "coredns-6d4b75cb6d-m6m4q -n kube-system", containerResult)
+	require.NoError(t, err)
+	assert.Equal(t, "coredns-6d4b75cb6d-m6m4q -n kube-system -c debugger", res)
+
+	res, err = ProcessContainerPickResult("cp", "kube-system/coredns-6d4b75cb6d-m6m4q:", containerResult)
+	require.NoError(t, err)
+	assert.Equal(t, "-c debugger kube-system/coredns-6d4b75cb6d-m6m4q:", res)
+}
+
+func TestMultiResult(t *testing.T) {
+	testDatas := []struct {
+		fzfLines         []string
+		cmdUse           string
+		cmdArgs          []string
+		currentNamespace string
+		expectedResult   string
+	}{
+		{[]string{"kube-system etcd-minikube", "kube-system kube-proxy-gpqdt"}, "delete", []string{"pods", " "}, "kube-system", "etcd-minikube kube-proxy-gpqdt"},
+		{[]string{"kube-system etcd-minikube", "kube-system kube-proxy-gpqdt"}, "delete", []string{"pods", " "}, "default", "etcd-minikube kube-proxy-gpqdt -n kube-system"},
+		{[]string{"kube-system etcd-minikube", "kube-system kube-proxy-gpqdt"}, "get", []string{"pods", "-n", "kube-system", " "}, "default", "etcd-minikube kube-proxy-gpqdt"},
+		{[]string{"minikube Ready", "worker Ready"}, "label", []string{"nodes", " "}, "default", "minikube worker"},
+		{[]string{"pods kube-system etcd-minikube", "services kube-system kube-dns"}, "get", []string{"pods,svc", " "}, "default", "etcd-minikube kube-dns -n kube-system"},
+		{[]string{"pods kube-system etcd-minikube", "services kube-system kube-dns"}, "get", []string{"pod/a", "svc/b", " "}, "kube-system", "pods/etcd-minikube services/kube-dns"},
+		{[]string{"kube-system coredns 1 1 1 1", "kube-system kubectl-fzf 1 1 1 1"}, "delete", []string{"deploy/"}, "kube-system", "deploy/coredns deploy/kubectl-fzf"},
+	}
+	for _, testData := range testDatas {
+		res, err := processMultiResult(testData.cmdUse, testData.cmdArgs, testData.fzfLines, testData.currentNamespace)
+		require.NoError(t, err)
+		require.Equal(t, testData.expectedResult, res, "Fzf lines %s, cmdUse %s, cmdArgs %s", testData.fzfLines,
+			testData.cmdUse, testData.cmdArgs)
+	}
+
+	_, err := processMultiResult("delete", []string{"pods", " "}, []string{"kube-system etcd-minikube", "default kubectl-fzf-788969b7cb-vf85b"}, "default")
+	require.ErrorContains(t, err, "multiple namespaces (kube-system, default)")
+
+	_, err = processMultiResult("delete", []string{"pods", "-n", "default", " "}, []string{"kube-system etcd-minikube", "kube-system kube-proxy-gpqdt"}, "default")
+	require.ErrorContains(t, err, "namespace default is provided")
+}
@@ -6,7 +6,6 @@ import (
 	"encoding/gob"
 	"io"
 	"io/ioutil"
-	"os"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -14,7 +13,13 @@ import (
 
 func EncodeToFile(data interface{}, filePath string) error {
 	logrus.Debugf("Writing encoded data in %s", filePath)
+	return WriteFileAtomic(filePath, func(writer io.Writer) error {
+		return EncodeGob(data, writer)
+	})
+}
 
+// EncodeGob writes the gzipped gob encoding of data
+func EncodeGob(data interface{}, writer io.Writer) error {
 	var gobBuf bytes.Buffer
 	enc := gob.NewEncoder(&gobBuf)
 	err := enc.Encode(data)
@@ -22,18 +27,12 @@ func EncodeToFile(data interface{}, filePath string) error {
 		return errors.Wrap(err, "error encoding gob data")
 	}
 
-	writer, err := os.Create(filePath)
-	if err != nil {
-		return errors.Wrap(err, "error creating target file")
-	}
-	defer writer.Close()
-
 	archiver := gzip.NewWriter(writer)
-	archiver.Name = filePath
-	defer archiver.Close()
-
 	_, err = io.Copy(archiver, &gobBuf)
-	return err
+	if err != nil {
+		return err
+	}
+	return archiver.Close()
 }
 
 func LoadGobFromFile(e interface{}, filePath string) error {
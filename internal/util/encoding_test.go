@@ -3,6 +3,7 @@ package util
 import (
 	"io/ioutil"
 	"os"
+	"path"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -24,3 +25,18 @@ func TestEncoding(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "test", res)
 }
+
+func TestEncodingErrorKeepsFile(t *testing.T) {
+	filePath := path.Join(t.TempDir(), "encoding")
+	require.NoError(t, EncodeToFile("test", filePath))
+
+	err := EncodeToFile(make(chan int), filePath)
+	require.Error(t, err)
+
+	var res string
+	require.NoError(t, LoadGobFromFile(&res, filePath))
+	assert.Equal(t, "test", res)
+	files, err := os.ReadDir(path.Dir(filePath))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
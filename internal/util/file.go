@@ -1,8 +1,11 @@
 package util
 
 import (
+	"io"
 	"os"
+	"path"
 
+	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 )
 
@@ -15,3 +18,29 @@ func FileExists(filePath string) bool {
 	_, err := os.Stat(filePath)
 	return err == nil
 }
+
+// WriteFileAtomic writes the file in a temporary file renamed over the target
+// so readers never see a partially written file
+func WriteFileAtomic(filePath string, write func(io.Writer) error) error {
+	tmpFile, err := os.CreateTemp(path.Dir(filePath), path.Base(filePath)+".tmp")
+	if err != nil {
+		return errors.Wrap(err, "error creating temp file")
+	}
+	// Nothing to remove once renamed
+	defer os.Remove(tmpFile.Name())
+	err = write(tmpFile)
+	if err != nil {
+		tmpFile.Close()
+		return err
+	}
+	err = tmpFile.Chmod(0644)
+	if err != nil {
+		tmpFile.Close()
+		return errors.Wrap(err, "error setting temp file mode")
+	}
+	err = tmpFile.Close()
+	if err != nil {
+		return errors.Wrap(err, "error closing temp file")
+	}
+	return os.Rename(tmpFile.Name(), filePath)
+}